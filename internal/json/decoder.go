@@ -2,6 +2,7 @@ package json
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 )
 
@@ -13,6 +14,54 @@ type Decoder interface {
 	Decode(v any) error
 }
 
-func NewDecoder(r io.Reader) Decoder {
-	return json.NewDecoder(r)
+// DecoderOptions configures the Decoder returned by NewDecoder.
+type DecoderOptions struct {
+	// DisallowUnknownFields rejects the objects holding keys
+	// which do not match any field of the destination struct.
+	DisallowUnknownFields bool
+	// UseNumber decodes numbers into an any as a Number
+	// instead of a float64.
+	UseNumber bool
+	// SingleValue rejects the input holding data after the first value.
+	SingleValue bool
+}
+
+// Number is a JSON number literal, see DecoderOptions.UseNumber.
+type Number = json.Number
+
+// ErrTrailingData is returned by the decoders with the SingleValue
+// option when the first value is followed by more data.
+var ErrTrailingData = errors.New("json: unexpected data after top-level value")
+
+func NewDecoder(r io.Reader, opts ...DecoderOptions) Decoder {
+	d := newDecoder(r, opts)
+	if len(opts) == 0 || !opts[0].SingleValue {
+		return d
+	}
+	return singleValueDecoder{d}
+}
+
+func newDecoder(r io.Reader, opts []DecoderOptions) *json.Decoder {
+	d := json.NewDecoder(r)
+	if len(opts) > 0 && opts[0].DisallowUnknownFields {
+		d.DisallowUnknownFields()
+	}
+	if len(opts) > 0 && opts[0].UseNumber {
+		d.UseNumber()
+	}
+	return d
+}
+
+type singleValueDecoder struct {
+	*json.Decoder
+}
+
+func (d singleValueDecoder) Decode(v any) error {
+	if err := d.Decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return ErrTrailingData
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/i9si-sistemas/assert"
@@ -30,3 +31,22 @@ func TestJSONDecoder(t *testing.T) {
 	assert.Equal(t, gopher.Name, "gopher")
 	assert.Equal(t, gopher.Working, true)
 }
+
+func TestJSONDecoderOptions(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	err := NewDecoder(bytes.NewBufferString(`{"name":"gopher","age":30}`), DecoderOptions{DisallowUnknownFields: true}).Decode(&v)
+	assert.Error(t, err)
+
+	var n any
+	err = NewDecoder(bytes.NewBufferString(`12345678901234567890`), DecoderOptions{UseNumber: true}).Decode(&n)
+	assert.NoError(t, err)
+	assert.Equal(t, n, any(Number("12345678901234567890")))
+
+	err = NewDecoder(bytes.NewBufferString(`{"name":"gopher"} {}`), DecoderOptions{SingleValue: true}).Decode(&v)
+	assert.Equal(t, err, ErrTrailingData)
+	err = NewDecoder(bytes.NewBufferString(`{"name":"gopher"}`+"\n"), DecoderOptions{SingleValue: true}).Decode(&v)
+	assert.NoError(t, err)
+	assert.NoError(t, NewDecoder(bytes.NewBufferString(`{"name":"gopher"} {}`)).Decode(&v))
+}
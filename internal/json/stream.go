@@ -0,0 +1,69 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Items returns the elements of the JSON array read from r, decoded one
+// by one as they are read. The iteration stops at the first error.
+func Items[T any](r io.Reader, opts ...DecoderOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		d := newDecoder(r, opts)
+		tok, err := d.Token()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			yield(zero, fmt.Errorf("json: expected an array, got %v", tok))
+			return
+		}
+		for d.More() {
+			var item T
+			if err := d.Decode(&item); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if _, err := d.Token(); err != nil {
+			yield(zero, err)
+			return
+		}
+		if len(opts) > 0 && opts[0].SingleValue {
+			if _, err := d.Token(); err != io.EOF {
+				yield(zero, ErrTrailingData)
+			}
+		}
+	}
+}
+
+// Lines returns the values of the newline delimited JSON read from r,
+// decoded one by one as they are read. The iteration stops at the
+// first error.
+func Lines[T any](r io.Reader, opts ...DecoderOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		d := newDecoder(r, opts)
+		for {
+			var item T
+			err := d.Decode(&item)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
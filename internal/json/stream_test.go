@@ -0,0 +1,76 @@
+package json
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+type streamItem struct {
+	ID int `json:"id"`
+}
+
+func TestItems(t *testing.T) {
+	var ids []int
+	for item, err := range Items[streamItem](bytes.NewBufferString(`[{"id":1}, {"id":2} ,{"id":3}]`)) {
+		assert.NoError(t, err)
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, ids, []int{1, 2, 3})
+
+	for item, err := range Items[streamItem](bytes.NewBufferString(`[{"id":1}]`)) {
+		assert.NoError(t, err)
+		assert.Equal(t, item.ID, 1)
+		break
+	}
+
+	tests := []struct {
+		input string
+		opts  DecoderOptions
+		items int
+	}{
+		{`{"id":1}`, DecoderOptions{}, 0},
+		{`[{"id":1},{"id":"2"}]`, DecoderOptions{}, 1},
+		{`[{"id":1},{"id":2,"name":"gopher"}]`, DecoderOptions{DisallowUnknownFields: true}, 1},
+		{`[{"id":1},{"id":2}`, DecoderOptions{}, 2},
+		{`[{"id":1}] []`, DecoderOptions{SingleValue: true}, 1},
+	}
+	for _, tt := range tests {
+		items, failed := 0, false
+		for _, err := range Items[streamItem](bytes.NewBufferString(tt.input), tt.opts) {
+			if err != nil {
+				failed = true
+				continue
+			}
+			items++
+		}
+		assert.True(t, failed, tt.input)
+		assert.Equal(t, items, tt.items, tt.input)
+	}
+}
+
+func TestLines(t *testing.T) {
+	var values []any
+	for value, err := range Lines[any](bytes.NewBufferString("{\"id\":1}\n\n{\"id\":2}\n3\n"), DecoderOptions{UseNumber: true}) {
+		assert.NoError(t, err)
+		values = append(values, value)
+	}
+	assert.Equal(t, values, []any{
+		map[string]any{"id": Number("1")},
+		map[string]any{"id": Number("2")},
+		Number("3"),
+	})
+
+	var ids []int
+	var failed bool
+	for item, err := range Lines[streamItem](bytes.NewBufferString("{\"id\":1}\n{\"id\":\n")) {
+		if err != nil {
+			failed = true
+			continue
+		}
+		ids = append(ids, item.ID)
+	}
+	assert.True(t, failed)
+	assert.Equal(t, ids, []int{1})
+}
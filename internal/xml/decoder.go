@@ -1,6 +1,7 @@
 package xml
 
 import (
+	"bytes"
 	"encoding/xml"
 	"errors"
 	"io"
@@ -79,3 +80,57 @@ func parseElement(d *xml.Decoder, start xml.StartElement) (map[string]any, error
 
 	return stack[0].result, nil
 }
+
+type Decoder interface {
+	Decode(v any) error
+}
+
+// DecoderOptions configures the Decoder returned by NewDecoder.
+type DecoderOptions struct {
+	// SingleValue rejects the input holding elements or text
+	// after the first element.
+	SingleValue bool
+}
+
+// ErrTrailingData is returned by the decoders with the SingleValue
+// option when the first element is followed by more data.
+var ErrTrailingData = errors.New("xml: unexpected data after root element")
+
+// NewDecoder returns a Decoder reading XML documents into structs from r.
+func NewDecoder(r io.Reader, opts ...DecoderOptions) Decoder {
+	d := xml.NewDecoder(r)
+	if len(opts) > 0 && opts[0].SingleValue {
+		return singleValueDecoder{d}
+	}
+	return d
+}
+
+type singleValueDecoder struct {
+	*xml.Decoder
+}
+
+// Decode decodes the first element, then checks that only
+// whitespace, comments and processing instructions follow it.
+func (d singleValueDecoder) Decode(v any) error {
+	if err := d.Decoder.Decode(v); err != nil {
+		return err
+	}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.Comment, xml.ProcInst:
+		case xml.CharData:
+			if len(bytes.TrimSpace(tok)) > 0 {
+				return ErrTrailingData
+			}
+		default:
+			return ErrTrailingData
+		}
+	}
+}
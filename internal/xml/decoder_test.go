@@ -47,3 +47,23 @@ func TestDecode(t *testing.T) {
 		assert.Equal(t, err, ErrInvalidXML)
 	})
 }
+
+func TestNewDecoderSingleValue(t *testing.T) {
+	var v struct {
+		Name string `xml:"name"`
+	}
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"<user><name>gopher</name></user>\n<!-- end -->\n", nil},
+		{"<user><name>gopher</name></user><user/>", ErrTrailingData},
+		{"<user><name>gopher</name></user> trailing", ErrTrailingData},
+	}
+	for _, tt := range tests {
+		err := NewDecoder(stringx.NewReader(tt.input), DecoderOptions{SingleValue: true}).Decode(&v)
+		assert.Equal(t, err, tt.err, tt.input)
+		assert.Equal(t, v.Name, "gopher")
+	}
+	assert.NoError(t, NewDecoder(stringx.NewReader(tests[1].input)).Decode(&v))
+}
@@ -0,0 +1,88 @@
+package xml
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Header is the XML declaration written before the encoded documents.
+const Header = xml.Header
+
+type Encoder interface {
+	Encode(v any) error
+}
+
+// NewEncoder returns an Encoder writing the XML encoding of values to w.
+//
+// Unlike encoding/xml, maps with string keys are supported: they are
+// encoded as a <response> element holding one element per key, in key
+// order, where slices become repeated elements.
+func NewEncoder(w io.Writer) Encoder {
+	return &encoder{xml.NewEncoder(w)}
+}
+
+type encoder struct {
+	*xml.Encoder
+}
+
+func (e *encoder) Encode(v any) error {
+	rv := indirect(reflect.ValueOf(v))
+	if !isMap(rv) {
+		return e.Encoder.Encode(v)
+	}
+	if err := e.encodeValue("response", rv); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+func (e *encoder) encodeValue(name string, v reflect.Value) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	v = indirect(v)
+	switch {
+	case !v.IsValid():
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	case isMap(v):
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, key := range keys {
+			if err := e.encodeValue(key.String(), v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		return e.EncodeToken(start.End())
+	case v.Kind() == reflect.Array, v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		for i := range v.Len() {
+			if err := e.encodeValue(name, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return e.EncodeElement(v.Interface(), start)
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isMap(v reflect.Value) bool {
+	return v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String
+}
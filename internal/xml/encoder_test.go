@@ -0,0 +1,39 @@
+package xml
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("Map", func(t *testing.T) {
+		type JSON map[string]any
+		var b bytes.Buffer
+		err := NewEncoder(&b).Encode(JSON{
+			"name": "Gabriel",
+			"age":  30,
+			"tags": []string{"go", "xml"},
+			"address": map[string]any{
+				"city": "Recife",
+			},
+			"nickname": nil,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, b.String(), "<response><address><city>Recife</city></address><age>30</age>"+
+			"<name>Gabriel</name><nickname></nickname><tags>go</tags><tags>xml</tags></response>")
+	})
+
+	t.Run("Struct", func(t *testing.T) {
+		type user struct {
+			XMLName struct{} `xml:"user"`
+			ID      int      `xml:"id,attr"`
+			Name    string   `xml:"name"`
+		}
+		var b bytes.Buffer
+		err := NewEncoder(&b).Encode(&user{ID: 1, Name: "Gabriel"})
+		assert.NoError(t, err)
+		assert.Equal(t, b.String(), `<user id="1"><name>Gabriel</name></user>`)
+	})
+}
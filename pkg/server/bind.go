@@ -0,0 +1,339 @@
+package server
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bindSources lists the struct tags read by Bind, in binding order.
+// The param tag of ParamsParser is accepted as an alias of path.
+var bindSources = []string{"path", "param", "query", "header", "cookie", "form"}
+
+// Bind fills the struct pointed by v from every part of the request.
+//
+// The body is decoded first, as JSON or XML according to its Content-Type,
+// when a field has a json or xml tag. Fields tagged path, query, header,
+// cookie or form are then set from the matching request values, using the
+// value of the default tag when the request has none.
+//
+//	var req struct {
+//		ID      int       `path:"id"`
+//		Tags    []string  `query:"tag"`
+//		Page    int       `query:"page" default:"1"`
+//		Since   time.Time `query:"since"`
+//		Token   string    `header:"Authorization"`
+//		Session *string   `cookie:"session"`
+//		Name    string    `json:"name"`
+//	}
+//	if err := c.Bind(&req); err != nil {
+//		return err // 400 Bad Request listing the invalid fields
+//	}
+//
+// Fields may be strings, booleans, numbers, time.Duration, types
+// implementing encoding.TextUnmarshaler such as time.Time, pointers
+// to them and slices of them, filled from repeated values. Untagged
+// struct fields are bound recursively. The errors of every field are
+// returned together as a *BindError. Once bound, v is checked with Validate.
+func (c *Context) Bind(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind: expected a pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	plan := bindPlanOf(rv.Type())
+	bindErr := new(BindError)
+	r := c.Request.HTTP()
+
+	if plan.body && hasBody(r) && !isForm(r.Header.Get("Content-Type")) {
+		if err := c.decodeBody(v); err != nil {
+			if srvErr := bodyError(err); srvErr.StatusCode != http.StatusBadRequest {
+				return srvErr
+			}
+			bindErr.Errors = append(bindErr.Errors, &FieldError{Source: "body", Err: err})
+		}
+	}
+	if plan.form {
+		if err := c.Request.parseForm(); err != nil {
+			if srvErr := bodyError(err); srvErr.StatusCode != http.StatusBadRequest {
+				return srvErr
+			}
+		}
+	}
+	var (
+		params map[string]string
+		query  = r.URL.Query()
+	)
+	for _, f := range plan.fields {
+		var values []string
+		switch f.source {
+		case "path", "param":
+			if params == nil {
+				params = c.Request.params()
+			}
+			if value, ok := params[f.key]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[f.key]
+		case "header":
+			values = r.Header.Values(f.key)
+		case "cookie":
+			for _, cookie := range r.CookiesNamed(f.key) {
+				values = append(values, cookie.Value)
+			}
+		case "form":
+			values = r.PostForm[f.key]
+		}
+		if len(values) == 0 {
+			if !f.hasDefault {
+				continue
+			}
+			values = []string{f.def}
+			if f.slice {
+				values = strings.Split(f.def, ",")
+			}
+		}
+		if err := setField(rv.FieldByIndex(f.index), values); err != nil {
+			bindErr.Errors = append(bindErr.Errors, &FieldError{
+				Field:  f.field,
+				Source: f.source,
+				Key:    f.key,
+				Value:  strings.Join(values, ","),
+				Err:    err,
+			})
+		}
+	}
+	if len(bindErr.Errors) > 0 {
+		return bindErr
+	}
+	return Validate(v)
+}
+
+// defaultMaxMemory is the size of the multipart forms kept in memory,
+// the rest being stored in temporary files.
+const defaultMaxMemory = 32 << 20
+
+// BindError holds the errors of the fields that could not be bound.
+// It is replied with 400 Bad Request by DefaultErrorHandler.
+type BindError struct {
+	Errors []*FieldError
+}
+
+func (e *BindError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *BindError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// HTTPStatus returns the status code replied for the error.
+func (e *BindError) HTTPStatus() int {
+	return http.StatusBadRequest
+}
+
+// FieldError describes a request value that could not be bound to a field.
+type FieldError struct {
+	// Field is the path of the struct field, such as "Filter.Page".
+	Field string
+	// Source is the tag naming where the value comes from,
+	// such as "query", or "body" for body decoding errors.
+	Source string
+	// Key is the name of the value in its source.
+	Key   string
+	Value string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %v", e.Source, e.Err)
+	}
+	return fmt.Sprintf("%s %q: invalid value %q for %s: %v", e.Source, e.Key, e.Value, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type bindPlan struct {
+	fields []bindField
+	body   bool
+	form   bool
+}
+
+type bindField struct {
+	index      []int
+	field      string
+	source     string
+	key        string
+	def        string
+	hasDefault bool
+	slice      bool
+}
+
+var bindPlans sync.Map
+
+// bindPlanOf returns the cached binding plan of the struct type.
+func bindPlanOf(t reflect.Type) *bindPlan {
+	if plan, ok := bindPlans.Load(t); ok {
+		return plan.(*bindPlan)
+	}
+	plan := new(bindPlan)
+	plan.add(t, nil, "")
+	actual, _ := bindPlans.LoadOrStore(t, plan)
+	return actual.(*bindPlan)
+}
+
+func (p *bindPlan) add(t reflect.Type, index []int, prefix string) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		if _, ok := sf.Tag.Lookup("json"); ok {
+			p.body = true
+		}
+		if _, ok := sf.Tag.Lookup("xml"); ok {
+			p.body = true
+		}
+		source, key := bindTag(sf.Tag)
+		if source == "" {
+			if sf.Type.Kind() == reflect.Struct && !isTextUnmarshaler(sf.Type) {
+				p.add(sf.Type, fieldIndex, prefix+sf.Name+".")
+			}
+			continue
+		}
+		if key == "" {
+			key = sf.Name
+		}
+		def, hasDefault := sf.Tag.Lookup("default")
+		p.form = p.form || source == "form"
+		p.fields = append(p.fields, bindField{
+			index:      fieldIndex,
+			field:      prefix + sf.Name,
+			source:     source,
+			key:        key,
+			def:        def,
+			hasDefault: hasDefault,
+			slice:      sf.Type.Kind() == reflect.Slice && !isTextUnmarshaler(sf.Type),
+		})
+	}
+}
+
+func bindTag(tag reflect.StructTag) (source, key string) {
+	for _, source := range bindSources {
+		if value, ok := tag.Lookup(source); ok {
+			key, _, _ = strings.Cut(value, ",")
+			if key == "-" {
+				return "", ""
+			}
+			return source, key
+		}
+	}
+	return "", ""
+}
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+func isTextUnmarshaler(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setField sets the field from the request values, every value
+// filling an element when the field is a slice.
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Slice && !isTextUnmarshaler(field.Type()) {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setValue(field, values[0])
+}
+
+var errUnsupportedType = errors.New("unsupported field type")
+
+func setValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setValue(v.Elem(), s)
+	}
+	if isTextUnmarshaler(v.Type()) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, v.Type())
+	}
+	return nil
+}
+
+// hasBody reports whether the request was sent with a body.
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+func isForm(contentType string) bool {
+	t := mediaType(contentType)
+	return t == "application/x-www-form-urlencoded" || t == "multipart/form-data"
+}
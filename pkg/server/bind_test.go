@@ -0,0 +1,117 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+type bindPagination struct {
+	Page  int `query:"page" default:"1"`
+	Limit int `query:"limit" default:"20"`
+}
+
+type bindRequest struct {
+	ID        int           `path:"id"`
+	Slug      string        `param:"slug"`
+	Tags      []string      `query:"tag"`
+	Scores    []float64     `query:"score" default:"1.5,2"`
+	Since     time.Time     `query:"since"`
+	Timeout   time.Duration `query:"timeout"`
+	Verbose   *bool         `query:"verbose"`
+	Token     string        `header:"Authorization"`
+	Languages []string      `header:"Accept-Language"`
+	Session   *string       `cookie:"session"`
+	Name      string        `json:"name"`
+	Age       int           `json:"age"`
+	Ignored   string        `query:"-"`
+	bindPagination
+}
+
+func TestBind(t *testing.T) {
+	s := New(0)
+	var bound bindRequest
+	s.Post("/users/:id/:slug", func(c *Context) error {
+		bound = bindRequest{}
+		if err := c.Bind(&bound); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	target := "/users/42/gopher?tag=a&tag=b&since=2024-01-02T15:04:05Z&timeout=1m30s&verbose=true&page=3&Ignored=x"
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"name":"Gabriel","age":23}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Add("Accept-Language", "pt-BR")
+	req.Header.Add("Accept-Language", "en")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+
+	assert.Equal(t, bound.ID, 42)
+	assert.Equal(t, bound.Slug, "gopher")
+	assert.Equal(t, bound.Tags, []string{"a", "b"})
+	assert.Equal(t, bound.Scores, []float64{1.5, 2})
+	assert.Equal(t, bound.Since, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC))
+	assert.Equal(t, bound.Timeout, 90*time.Second)
+	assert.True(t, *bound.Verbose)
+	assert.Equal(t, bound.Token, "Bearer token")
+	assert.Equal(t, bound.Languages, []string{"pt-BR", "en"})
+	assert.Equal(t, *bound.Session, "s3cr3t")
+	assert.Equal(t, bound.Name, "Gabriel")
+	assert.Equal(t, bound.Age, 23)
+	assert.Empty(t, bound.Ignored)
+	assert.Equal(t, bound.Page, 3)
+	assert.Equal(t, bound.Limit, 20)
+	assert.Equal(t, bindPlanOf(reflect.TypeFor[bindRequest]()), bindPlanOf(reflect.TypeFor[bindRequest]()))
+
+	req = httptest.NewRequest(http.MethodPost, "/users/42/gopher?page=x&since=yesterday&score=1&score=two", nil)
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	for _, field := range []string{"bindPagination.Page", "Since", "Scores"} {
+		assert.True(t, strings.Contains(w.Body.String(), field), field)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	var form struct {
+		Name   string   `form:"name"`
+		Colors []string `form:"color"`
+		Page   int      `query:"page"`
+	}
+	values := url.Values{"name": {"gopher"}, "color": {"blue", "green"}}
+	req := httptest.NewRequest(http.MethodPost, "/?page=2", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c := NewContext(req.Context(), req, httptest.NewRecorder())
+	assert.NoError(t, c.Bind(&form))
+	assert.Equal(t, form.Name, "gopher")
+	assert.Equal(t, form.Colors, []string{"blue", "green"})
+	assert.Equal(t, form.Page, 2)
+
+	var bindErr *BindError
+	var invalid struct {
+		Page  int      `query:"page"`
+		Ch    chan int `query:"ch"`
+		Limit uint8    `query:"limit"`
+	}
+	req = httptest.NewRequest(http.MethodGet, "/?page=1&ch=1&limit=256", nil)
+	c = NewContext(req.Context(), req, httptest.NewRecorder())
+	err := c.Bind(&invalid)
+	assert.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, len(bindErr.Errors), 2)
+	assert.Equal(t, bindErr.Errors[0].Field, "Ch")
+	assert.True(t, errors.Is(err, errUnsupportedType))
+	assert.Equal(t, bindErr.Errors[1].Key, "limit")
+	assert.Equal(t, bindErr.Errors[1].Value, "256")
+	assert.Equal(t, invalid.Page, 1)
+
+	assert.NotNil(t, c.Bind(invalid))
+}
@@ -2,7 +2,8 @@ package server
 
 import "github.com/i9si-sistemas/nine/internal/json"
 
-// Body decodes the JSON body of an HTTP request into a provided variable.
+// Body decodes the JSON body of an HTTP request into a provided variable,
+// then checks it with Validate.
 //
 //	var body bodyType
 //	if err := nine.Body(req, &body); err != nil {
@@ -11,5 +12,12 @@ import "github.com/i9si-sistemas/nine/internal/json"
 //		})
 //	}
 func Body[T any](req *Request, v *T) error {
-	return json.Decode(req.Body().Bytes(), v)
+	b, err := req.readBody()
+	if err != nil {
+		return bodyError(err)
+	}
+	if err := json.Decode(b, v); err != nil {
+		return err
+	}
+	return Validate(v)
 }
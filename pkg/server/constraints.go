@@ -0,0 +1,120 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// constraint reports whether a path parameter value is accepted by a route.
+type constraint func(value string) bool
+
+// constraints holds the named constraints available in route patterns.
+//
+//	server.Get("/users/:id<int>", showUser)
+//	server.Get("/tokens/:token<uuid>", showToken)
+//
+// Any other constraint is compiled as a regular expression that must
+// match the whole segment.
+//
+//	server.Get("/posts/:slug<[a-z0-9-]+>", showPost)
+var constraints = map[string]constraint{
+	"int":   isInt,
+	"uint":  isDigits,
+	"alpha": isAlpha,
+	"alnum": isAlnum,
+	"uuid":  isUUID,
+}
+
+var compiledConstraints sync.Map
+
+// compileConstraint returns the constraint for the given expression.
+func compileConstraint(expr string) (constraint, error) {
+	if c, ok := constraints[expr]; ok {
+		return c, nil
+	}
+	if c, ok := compiledConstraints.Load(expr); ok {
+		return c.(constraint), nil
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid param constraint <%s>: %w", expr, err)
+	}
+	c, _ := compiledConstraints.LoadOrStore(expr, constraint(re.MatchString))
+	return c.(constraint), nil
+}
+
+func mustCompileConstraint(expr string) constraint {
+	c, err := compileConstraint(expr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func isInt(s string) bool {
+	if len(s) > 1 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	return isDigits(s)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isLetter(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isLetter(s[i]) && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			c := s[i]
+			if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
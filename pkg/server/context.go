@@ -4,12 +4,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"mime/multipart"
 	"net/http"
 	"os"
 	"reflect"
-	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/i9si-sistemas/nine/internal/json"
 )
@@ -37,25 +36,7 @@ func NewContext(
 
 // ParamsParser parses the path parameters into the provided struct pointer.
 func (c *Context) ParamsParser(v any) error {
-	pattern := c.pathRegistred()
-	path := c.Request.HTTP().URL.Path
-
-	re := regexp.MustCompile(`{([^/]+)}`)
-	pattern = re.ReplaceAllString(pattern, `(?P<$1>[^/]+)`)
-
-	re = regexp.MustCompile(`:([^/]+)`)
-	pattern = re.ReplaceAllString(pattern, `(?P<$1>[^/]+)`)
-
-	regex := regexp.MustCompile(pattern)
-	match := regex.FindStringSubmatch(path)
-	params := make(map[string]string)
-	if match != nil {
-		for i, name := range regex.SubexpNames() {
-			if i > 0 && name != "" {
-				params[name] = match[i]
-			}
-		}
-	}
+	params := c.Request.params()
 
 	val := reflect.ValueOf(v).Elem()
 	typ := val.Type()
@@ -179,9 +160,24 @@ func (c *Context) SendFile(filePath string) error {
 	return c.Send(b)
 }
 
-// BodyParser parses the request body into the provided struct pointer.
+// BodyParser parses the request body into the provided struct pointer,
+// according to its Content-Type. JSON bodies, also assumed without
+// Content-Type, XML bodies sent as application/xml, text/xml or any +xml
+// media type, and url-encoded or multipart forms are supported; other
+// media types are rejected with 415 Unsupported Media Type, bodies
+// exceeding the BodyLimit of the route with 413 Request Entity Too Large
+// and malformed bodies with 400 Bad Request, see DecodeOptions for the
+// strict checks. The decoded value is then checked with Validate.
 func (c *Context) BodyParser(v any) error {
-	return json.NewDecoder(c.Request.Body()).Decode(v)
+	if err := c.decodeBody(v); err != nil {
+		return bodyError(err)
+	}
+	return Validate(v)
+}
+
+func isXML(contentType string) bool {
+	t := mediaType(contentType)
+	return t == "application/xml" || t == "text/xml" || strings.HasSuffix(t, "+xml")
 }
 
 // QueryParser parses the query string into the provided struct pointer.
@@ -195,12 +191,7 @@ func (c *Context) QueryParser(v any) error {
 		}
 	}
 
-	data, err := json.Marshal(simplifiedQuery)
-	if err != nil {
-		return err
-	}
-
-	return json.Decode(data, v)
+	return parseForm(simplifiedQuery, v)
 }
 
 // ReqHeaderParser parses the request headers into the provided struct pointer.
@@ -240,10 +231,10 @@ func (c *Context) IPs() []string {
 }
 
 // Body returns the request body as a byte slice.
+// It is the body cached by Request.Body and must not be modified.
 func (c *Context) Body() []byte {
-	body := c.Request.Body()
-	defer body.Reset()
-	return body.Bytes()
+	b, _ := c.Request.readBody()
+	return b
 }
 
 // Query returns the value of the specified query parameter.
@@ -264,15 +255,6 @@ func (c *Context) Params(name string, defaultValue ...string) string {
 	return value
 }
 
-// FormFile returns the file associated with the specified form key.
-func (c *Context) FormFile(key string) (*multipart.FileHeader, error) {
-	_, header, err := c.Request.HTTP().FormFile(key)
-	if err != nil {
-		return nil, err
-	}
-	return header, nil
-}
-
 // SendStatus sends a status code as the response body.
 func (c *Context) SendStatus(status int) error {
 	return c.Response.SendStatus(status)
@@ -302,8 +284,13 @@ func (c *Context) JSON(data any) error {
 	return c.Response.JSON(payload)
 }
 
-func (c *Context) pathRegistred() string {
-	return c.Request.PathRegistred()
+// XML sends an XML-encoded response.
+//
+//	return c.XML(i9.JSON{"status": "ok"})
+//	// <?xml version="1.0" encoding="UTF-8"?>
+//	// <response><status>ok</status></response>
+func (c *Context) XML(data any) error {
+	return c.Response.XML(data)
 }
 
 func parseForm(form any, v any) error {
@@ -3,6 +3,8 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -31,7 +33,7 @@ func TestContext(t *testing.T) {
 	assert.NotNil(t, ctx)
 	assert.Equal(t, c.ctx, ctx)
 	assert.Equal(t, c.Request.HTTP(), req)
-	assert.Equal(t, c.Response.res, res)
+	assert.Equal(t, c.Response.writer.Unwrap(), res)
 }
 
 func TestBodyParser(t *testing.T) {
@@ -170,6 +172,57 @@ func TestContextJSON(t *testing.T) {
 	assert.Equal(t, jsonResponse["message"], "success")
 }
 
+func TestContextXML(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	res := httptest.NewRecorder()
+	c := NewContext(context.Background(), req, res)
+
+	err := c.Status(http.StatusCreated).XML(JSON{"message": "success", "tags": []string{"a", "b"}})
+	assert.Nil(t, err)
+	assert.Equal(t, res.Code, http.StatusCreated)
+	assert.Equal(t, res.Header().Get("Content-Type"), "application/xml; charset=utf-8")
+	assert.Equal(t, res.Body.String(), `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		"<response><message>success</message><tags>a</tags><tags>b</tags></response>")
+
+	type user struct {
+		XMLName xml.Name `xml:"user"`
+		Name    string   `xml:"name"`
+	}
+	res = httptest.NewRecorder()
+	c = NewContext(context.Background(), req, res)
+	assert.Nil(t, c.XML(user{Name: "test"}))
+	assert.Equal(t, res.Body.String(), xml.Header+"<user><name>test</name></user>")
+
+	req.Header.Set("Accept", "application/xml")
+	res = httptest.NewRecorder()
+	(&Error{StatusCode: http.StatusNotFound, ContentType: "application/xml", Err: errors.New("not found")}).ServeHTTP(res, req)
+	assert.Equal(t, res.Code, http.StatusNotFound)
+	assert.Equal(t, res.Body.String(), xml.Header+"<response><err>not found</err></response>")
+
+	res = httptest.NewRecorder()
+	(&Error{StatusCode: http.StatusNotFound, ContentType: "application/json; charset=utf-8", Err: errors.New("not found")}).ServeHTTP(res, req)
+	assert.Equal(t, res.Header().Get("Content-Type"), "application/json; charset=utf-8")
+	assert.Equal(t, res.Body.String(), `{"err":"not found"}`)
+}
+
+func TestBodyParserXML(t *testing.T) {
+	type envelope struct {
+		Name string `xml:"body>name"`
+		Age  int    `xml:"body>age"`
+	}
+	for _, contentType := range []string{"application/xml", "text/xml; charset=utf-8", "application/soap+xml"} {
+		body := `<envelope><body><name>test</name><age>30</age></body></envelope>`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		c := NewContext(context.Background(), req, httptest.NewRecorder())
+
+		var parsedBody envelope
+		assert.Nil(t, c.BodyParser(&parsedBody))
+		assert.Equal(t, parsedBody.Name, "test")
+		assert.Equal(t, parsedBody.Age, 30)
+	}
+}
+
 func TestFormFile(t *testing.T) {
 	body := new(bytes.Buffer)
 	writer := multipart.NewWriter(body)
@@ -151,3 +151,29 @@ func TestDefaultCorsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCorsExplicitOptions(t *testing.T) {
+	server := New(0)
+	Cors(server)
+	explicit := func(c *Context) error {
+		return c.SendString("explicit")
+	}
+	get := func(c *Context) error {
+		return c.SendString("get")
+	}
+	server.Options("/before", explicit)
+	server.Get("/before", get)
+	server.Get("/after", get)
+	server.Options("/after", explicit)
+	server.Match([]string{http.MethodPut, http.MethodOptions}, "/match", explicit)
+	server.Get("/match", get)
+	server.Get("/cors", get)
+
+	for _, path := range []string{"/before", "/after", "/match"} {
+		res := server.Test().Request(httptest.NewRequest(http.MethodOptions, path, nil))
+		assert.Equal(t, res.Code, http.StatusOK, path)
+		assert.Equal(t, res.Body.String(), "explicit", path)
+	}
+	res := server.Test().Request(httptest.NewRequest(http.MethodOptions, "/cors", nil))
+	assert.Equal(t, res.Code, http.StatusNoContent)
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/i9si-sistemas/nine/internal/json"
+	"github.com/i9si-sistemas/nine/internal/xml"
+)
+
+// DecodeOptions configures how BodyParser and Bind decode request bodies.
+// They are set for every route with ServerOpts.Decoding, or for a single
+// route with the Decoding option.
+type DecodeOptions struct {
+	// DisallowUnknownFields rejects the JSON and form bodies holding
+	// fields which do not match the destination struct.
+	DisallowUnknownFields bool
+	// UseNumber decodes JSON numbers into an any as a json.Number
+	// instead of a float64.
+	UseNumber bool
+	// SingleValue rejects the JSON and XML bodies holding data after
+	// their first value, and the form fields sent more than once for
+	// a destination which is not a slice.
+	SingleValue bool
+}
+
+// StrictDecoding enables every check of DecodeOptions.
+var StrictDecoding = DecodeOptions{
+	DisallowUnknownFields: true,
+	UseNumber:             true,
+	SingleValue:           true,
+}
+
+// Decoding sets the DecodeOptions of the route, replacing the
+// ones of the server.
+//
+//	server.Post("/users", i9.Decoding(i9.StrictDecoding), createUser)
+func Decoding(opts DecodeOptions) RouteOption {
+	return func(r *Router) {
+		r.decoding = &opts
+	}
+}
+
+// withDecoding makes the handler decode bodies with the options.
+func withDecoding(h Handler, opts *DecodeOptions) Handler {
+	return func(req *Request, res *Response) error {
+		req.decoding = opts
+		return h(req, res)
+	}
+}
+
+// decodeOptions returns the DecodeOptions of the route handling
+// the request, or the ones of the server.
+func (r *Request) decodeOptions() DecodeOptions {
+	if r.decoding != nil {
+		return *r.decoding
+	}
+	if s, ok := serverFromContext(r.Context()); ok {
+		return s.decoding
+	}
+	return DecodeOptions{}
+}
+
+// decodeBody decodes the body according to its Content-Type: JSON,
+// which is also assumed without Content-Type, XML, or form values.
+// Other media types are rejected with 415 Unsupported Media Type.
+func (c *Context) decodeBody(v any) error {
+	opts := c.Request.decodeOptions()
+	contentType := c.Header("Content-Type")
+	switch t := mediaType(contentType); {
+	case t == "", t == "application/json", strings.HasSuffix(t, "+json"):
+		b, err := c.Request.readBody()
+		if err != nil {
+			return err
+		}
+		return json.NewDecoder(bytes.NewReader(b), c.jsonOptions()).Decode(v)
+	case isXML(t):
+		b, err := c.Request.readBody()
+		if err != nil {
+			return err
+		}
+		return xml.NewDecoder(bytes.NewReader(b), xml.DecoderOptions{
+			SingleValue: opts.SingleValue,
+		}).Decode(v)
+	case isForm(t):
+		if err := c.Request.parseForm(); err != nil {
+			return err
+		}
+		return decodeForm(c.Request.HTTP().PostForm, v, opts)
+	}
+	return &Error{
+		StatusCode: http.StatusUnsupportedMediaType,
+		Err:        fmt.Errorf("unsupported media type %q", contentType),
+	}
+}
+
+// parseForm parses the url-encoded or multipart form of the body.
+func (r *Request) parseForm() error {
+	if mediaType(r.Header("Content-Type")) == "multipart/form-data" {
+		_, err := r.multipartForm()
+		return err
+	}
+	return r.req.ParseForm()
+}
+
+var errUnknownField = errors.New("unknown field")
+
+// decodeForm decodes form values into a struct, whose fields are matched
+// by their form or json tag, or into a map of strings, string slices or
+// values of any type.
+func decodeForm(form url.Values, v any, opts DecodeOptions) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("form: expected a pointer, got %T", v)
+	}
+	rv = rv.Elem()
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		return decodeFormMap(form, rv, opts)
+	case rv.Kind() != reflect.Struct:
+		return fmt.Errorf("form: cannot decode into %T", v)
+	}
+	bindErr := new(BindError)
+	known := make(map[string]bool)
+	for _, f := range formPlanOf(rv.Type()) {
+		known[f.key] = true
+		values := form[f.key]
+		if len(values) == 0 {
+			continue
+		}
+		if opts.SingleValue && !f.slice && len(values) > 1 {
+			bindErr.Errors = append(bindErr.Errors, &FieldError{
+				Field:  f.field,
+				Source: "form",
+				Key:    f.key,
+				Value:  strings.Join(values, ","),
+				Err:    errMultipleValues,
+			})
+			continue
+		}
+		if err := setField(rv.FieldByIndex(f.index), values); err != nil {
+			bindErr.Errors = append(bindErr.Errors, &FieldError{
+				Field:  f.field,
+				Source: "form",
+				Key:    f.key,
+				Value:  strings.Join(values, ","),
+				Err:    err,
+			})
+		}
+	}
+	if opts.DisallowUnknownFields {
+		for key, values := range form {
+			if !known[key] {
+				bindErr.Errors = append(bindErr.Errors, &FieldError{
+					Source: "form",
+					Key:    key,
+					Value:  strings.Join(values, ","),
+					Err:    errUnknownField,
+				})
+			}
+		}
+	}
+	if len(bindErr.Errors) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+var errMultipleValues = errors.New("multiple values")
+
+func decodeFormMap(form url.Values, m reflect.Value, opts DecodeOptions) error {
+	if m.IsNil() {
+		m.Set(reflect.MakeMapWithSize(m.Type(), len(form)))
+	}
+	elem := m.Type().Elem()
+	for key, values := range form {
+		var value reflect.Value
+		switch {
+		case elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.String:
+			value = reflect.ValueOf(values).Convert(elem)
+		case opts.SingleValue && len(values) > 1:
+			return &FieldError{Source: "form", Key: key, Value: strings.Join(values, ","), Err: errMultipleValues}
+		case elem.Kind() == reflect.String:
+			value = reflect.ValueOf(values[0]).Convert(elem)
+		case elem.Kind() == reflect.Interface && elem.NumMethod() == 0:
+			value = reflect.ValueOf(any(values[0]))
+			if len(values) > 1 {
+				value = reflect.ValueOf(any(values))
+			}
+		default:
+			return fmt.Errorf("form: cannot decode into %s", m.Type())
+		}
+		m.SetMapIndex(reflect.ValueOf(key).Convert(m.Type().Key()), value)
+	}
+	return nil
+}
+
+var formPlans sync.Map
+
+// formPlanOf returns the cached fields of the struct type
+// decoded from form bodies.
+func formPlanOf(t reflect.Type) []bindField {
+	if plan, ok := formPlans.Load(t); ok {
+		return plan.([]bindField)
+	}
+	plan := addFormFields(nil, t, nil)
+	actual, _ := formPlans.LoadOrStore(t, plan)
+	return actual.([]bindField)
+}
+
+func addFormFields(fields []bindField, t reflect.Type, index []int) []bindField {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && sf.Tag.Get("form") == "" && sf.Tag.Get("json") == "" {
+			fields = addFormFields(fields, sf.Type, fieldIndex)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(sf.Tag.Get("form"), ",")
+		if key == "" {
+			key, _, _ = strings.Cut(sf.Tag.Get("json"), ",")
+		}
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = sf.Name
+		}
+		fields = append(fields, bindField{
+			index:  fieldIndex,
+			field:  sf.Name,
+			source: "form",
+			key:    key,
+			slice:  sf.Type.Kind() == reflect.Slice && !isTextUnmarshaler(sf.Type),
+		})
+	}
+	return fields
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+type decodeUser struct {
+	Name  string   `json:"name" xml:"name" form:"name"`
+	Age   int      `json:"age" xml:"age"`
+	Roles []string `json:"roles" xml:"role" form:"role"`
+}
+
+func TestBodyParserContentTypes(t *testing.T) {
+	s := New(0)
+	var user decodeUser
+	handler := func(c *Context) error {
+		user = decodeUser{}
+		if err := c.BodyParser(&user); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	}
+	s.Post("/users", handler)
+	s.Post("/strict/users", Decoding(StrictDecoding), handler)
+
+	var multipartBody bytes.Buffer
+	mw := multipart.NewWriter(&multipartBody)
+	mw.WriteField("name", "gopher")
+	mw.WriteField("age", "15")
+	mw.WriteField("role", "admin")
+	mw.WriteField("role", "user")
+	mw.Close()
+
+	tests := []struct {
+		path, contentType, body string
+		code                    int
+	}{
+		{"/users", "", `{"name":"gopher","age":15,"roles":["admin","user"]}`, http.StatusNoContent},
+		{"/users", "application/merge-patch+json", `{"name":"gopher","age":15,"roles":["admin","user"]}`, http.StatusNoContent},
+		{"/users", "text/xml", `<user><name>gopher</name><age>15</age><role>admin</role><role>user</role></user>`, http.StatusNoContent},
+		{"/users", "application/x-www-form-urlencoded", "name=gopher&age=15&role=admin&role=user&extra=1", http.StatusNoContent},
+		{"/users", mw.FormDataContentType(), multipartBody.String(), http.StatusNoContent},
+		{"/users", "application/json", `{"name":"gopher","age":15,"roles":["admin","user"],"extra":1} {}`, http.StatusNoContent},
+		{"/users", "application/json", `{"name":`, http.StatusBadRequest},
+		{"/users", "text/csv", "name,age", http.StatusUnsupportedMediaType},
+		{"/strict/users", "application/json", `{"name":"gopher","age":15,"roles":["admin","user"]}`, http.StatusNoContent},
+		{"/strict/users", "application/json", `{"name":"gopher","extra":1}`, http.StatusBadRequest},
+		{"/strict/users", "application/json", `{"name":"gopher"} {}`, http.StatusBadRequest},
+		{"/strict/users", "application/xml", `<user><name>gopher</name></user><user/>`, http.StatusBadRequest},
+		{"/strict/users", "application/x-www-form-urlencoded", "name=gopher&extra=1", http.StatusBadRequest},
+		{"/strict/users", "application/x-www-form-urlencoded", "name=gopher&name=gordon", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.contentType)
+		w := s.Test().Request(req)
+		assert.Equal(t, w.Code, tt.code, tt.path, tt.contentType, tt.body)
+		if tt.code == http.StatusNoContent && tt.path == "/users" {
+			assert.Equal(t, user, decodeUser{Name: "gopher", Age: 15, Roles: []string{"admin", "user"}}, tt.contentType)
+		}
+	}
+}
+
+func TestBodyParserServerDecoding(t *testing.T) {
+	s := New(0, ServerOpts{Decoding: DecodeOptions{UseNumber: true}})
+	s.Post("/", func(c *Context) error {
+		var body map[string]any
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+		assert.Equal(t, body["id"], any(json.Number("9007199254740993")))
+		return c.SendStatus(http.StatusNoContent)
+	})
+	s.Post("/form", Decoding(DecodeOptions{SingleValue: true}), func(c *Context) error {
+		var body map[string]any
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+		return c.JSON(body)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":9007199254740993}`))
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+
+	req = httptest.NewRequest(http.MethodPost, "/form", strings.NewReader("name=gopher"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Body.String(), `{"name":"gopher"}`+"\n")
+
+	req = httptest.NewRequest(http.MethodPost, "/form", strings.NewReader("name=gopher&name=gordon"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":`))
+	c := NewContext(req.Context(), req, httptest.NewRecorder())
+	var body map[string]any
+	err := c.BodyParser(&body)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	var srvErr *Error
+	assert.True(t, errors.As(err, &srvErr))
+	assert.Equal(t, srvErr.StatusCode, http.StatusBadRequest)
+}
@@ -1,6 +1,9 @@
 package server
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
 
 type Error struct {
 	StatusCode  int
@@ -12,17 +15,27 @@ func (e *Error) Error() string {
 	return e.Err.Error()
 }
 
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ServeHTTP replies with the error message in the ContentType of the error.
+// Without ContentType, the message is sent as plain text, JSON or XML
+// according to the Accept header of the request.
 func (e *Error) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if e.Err != nil {
-		w.Header().Set("Content-Type", e.ContentType)
+		contentType := e.ContentType
+		if contentType == "" {
+			req := NewRequest(r)
+			contentType = mediaType(req.Accepts("text/plain", "application/json", "application/xml"))
+		}
+		w.Header().Set("Content-Type", contentType)
 
-		if e.ContentType == "application/json" {
+		if mediaType(contentType) == "application/json" {
 			if e.StatusCode >= 100 {
 				w.WriteHeader(e.StatusCode)
 			}
-			b, err := JSON{
-				"err": e.Err.Error(),
-			}.Bytes()
+			b, err := errorBody(e.Err).Bytes()
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
@@ -31,7 +44,89 @@ func (e *Error) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if mediaType(contentType) == "application/xml" {
+			res := NewResponse(w)
+			if err := res.Status(e.StatusCode).XML(errorBody(e.Err)); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
 		http.Error(w, e.Err.Error(), e.StatusCode)
 		return
 	}
 }
+
+// errorBody returns the JSON and XML body replied for the error,
+// listing the failing fields of a *ValidationError.
+func errorBody(err error) JSON {
+	body := JSON{"err": err.Error()}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		body["errors"] = validationErr.Errors
+	}
+	return body
+}
+
+// statusError is implemented by the errors replied with
+// their own status code, such as *BindError and *ValidationError.
+type statusError interface {
+	error
+	HTTPStatus() int
+}
+
+// DefaultErrorHandler replies with the *Error found in the chain of err,
+// with the status code of the errors such as *BindError and
+// *ValidationError, or with 500 Internal Server Error and the error
+// message otherwise. The message of a *PanicError is not replied.
+//
+//	server := i9.New(8080, i9.ServerOpts{
+//		ErrorHandler: func(c *i9.Context, err error) {
+//			if errors.Is(err, sql.ErrNoRows) {
+//				err = &i9.Error{StatusCode: http.StatusNotFound, Err: err}
+//			}
+//			i9.DefaultErrorHandler(c, err)
+//		},
+//	})
+func DefaultErrorHandler(c *Context, err error) {
+	var srvErr *Error
+	if errors.As(err, &srvErr) && srvErr != nil {
+		srvErr.ServeHTTP(c.Response.HTTP(), c.Request.HTTP())
+		return
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		bodyError(err).ServeHTTP(c.Response.HTTP(), c.Request.HTTP())
+		return
+	}
+	var statusErr statusError
+	if errors.As(err, &statusErr) {
+		srvErr = &Error{StatusCode: statusErr.HTTPStatus(), Err: statusErr}
+		srvErr.ServeHTTP(c.Response.HTTP(), c.Request.HTTP())
+		return
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		http.Error(c.Response.HTTP(), http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	http.Error(c.Response.HTTP(), err.Error(), http.StatusInternalServerError)
+}
+
+// handleError replies to the request with the error returned by a handler
+// or middleware, through the ErrorHandler of the server handling it.
+// Only the first error of a request is replied.
+func handleError(req *Request, res *Response, err error) {
+	if req.errorHandled {
+		return
+	}
+	req.errorHandled = true
+	handler := DefaultErrorHandler
+	if s, ok := serverFromContext(req.Context()); ok && s.errorHandler != nil {
+		handler = s.errorHandler
+	}
+	c := NewContext(req.Context(), req.HTTP(), res.HTTP())
+	c.Request = req
+	c.Response = res
+	handler(c, err)
+}
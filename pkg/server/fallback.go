@@ -0,0 +1,103 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// NotFound sets the handler replying to the requests that match no route.
+// Set on a group, it only replies to the requests under the group's base
+// path, the most specific one winning. Global middlewares run before it,
+// as they do for the routes.
+//
+//	server.NotFound(func(c *i9.Context) error {
+//		return c.Status(http.StatusNotFound).SendString("page not found")
+//	})
+//	server.Group("/api").NotFound(func(c *i9.Context) error {
+//		return c.Status(http.StatusNotFound).JSON(i9.JSON{"error": "not found"})
+//	})
+//
+// Fallback handlers are only supported by the built-in router.
+func (s *Server) NotFound(handlers ...any) error {
+	return s.fallback(&s.notFound, handlers...)
+}
+
+// MethodNotAllowed sets the handler replying to the requests whose path
+// matches a route registered for other methods. The Allow header is set
+// before the handler runs. See NotFound.
+func (s *Server) MethodNotAllowed(handlers ...any) error {
+	return s.fallback(&s.methodNotAllowed, handlers...)
+}
+
+// NotFound sets the handler replying to the requests under the group that match no route.
+func (g *RouteGroup) NotFound(handlers ...any) error {
+	return g.server.NotFound(g.routeHandlers(handlers...)...)
+}
+
+// MethodNotAllowed sets the handler replying to the requests under the group
+// whose path matches a route registered for other methods.
+func (g *RouteGroup) MethodNotAllowed(handlers ...any) error {
+	return g.server.MethodNotAllowed(g.routeHandlers(handlers...)...)
+}
+
+func (s *Server) fallback(routes *Routes, handlers ...any) error {
+	options, handlers := routeOptions(handlers)
+	handler, middlewares, err := registerHandlers(handlers...)
+	if err != nil {
+		return err
+	}
+	route := Router{
+		handler:     handler,
+		handlerName: handlerName(handlers[len(handlers)-1]),
+		middlewares: middlewares,
+	}
+	for _, option := range options {
+		option(&route)
+	}
+	*routes = append(*routes, route)
+	return nil
+}
+
+// fallbackHandler returns the handler dispatching to the fallback route
+// registered for the longest prefix of the request path, or to a handler
+// replying with the given status code when there is none.
+func (s *Server) fallbackHandler(routes Routes, code int) http.Handler {
+	rt := s.newRouter()
+	registered := make(map[string]bool)
+	handle := func(pattern string, route Router) {
+		if !registered[pattern] {
+			registered[pattern] = true
+			route.pattern = pattern
+			rt.Handle(pattern, s.routeHandler(route))
+		}
+	}
+	// The last fallback set for a prefix replaces the previous ones.
+	for _, route := range slices.Backward(routes) {
+		host := s.transformPath(route.host)
+		prefix := strings.TrimSuffix(s.transformPath(route.prefix), "/")
+		handle(host+prefix+"/{fallback...}", route)
+		if prefix != "" {
+			handle(host+prefix, route)
+		}
+	}
+	handle("/{fallback...}", Router{handler: statusHandler(code)})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, values, _ := rt.lookup("", requestHost(r), r.URL.EscapedPath())
+		for i, name := range route.params {
+			r.SetPathValue(name, values[i])
+		}
+		r.Pattern = route.pattern
+		route.handler.ServeHTTP(w, r)
+	})
+}
+
+func statusHandler(code int) Handler {
+	return func(req *Request, res *Response) error {
+		return &Error{
+			StatusCode: code,
+			Err:        errors.New(http.StatusText(code)),
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestServerFallbacks(t *testing.T) {
+	s := New(0)
+	var logged []string
+	s.Use(func(req *Request, res *Response) error {
+		logged = append(logged, req.Path())
+		return nil
+	})
+	handler := func(c *Context) error {
+		return c.SendString(c.Method())
+	}
+	s.Get("/", handler)
+	s.Get("/api/users/:id", handler)
+	s.Get("/api/admin/users", handler)
+
+	assert.NoError(t, s.NotFound(func(c *Context) error {
+		return c.Status(http.StatusNotFound).SendString("<h1>page not found</h1>")
+	}))
+	api := s.Group("/api")
+	assert.NoError(t, api.NotFound(func(c *Context) error {
+		return c.Status(http.StatusNotFound).JSON(JSON{"error": "not found"})
+	}))
+	assert.NoError(t, api.MethodNotAllowed(func(c *Context) error {
+		return c.Status(http.StatusMethodNotAllowed).JSON(JSON{"error": "method not allowed"})
+	}))
+	api.Route("/admin", func(router RouteManager) {
+		router.NotFound(func(c *Context) error {
+			return c.Status(http.StatusNotFound).SendString("admin: " + c.Path())
+		})
+	})
+	assert.NotNil(t, s.NotFound("not a handler"))
+
+	tests := []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{http.MethodGet, "/about", http.StatusNotFound, "<h1>page not found</h1>"},
+		{http.MethodGet, "/apis", http.StatusNotFound, "<h1>page not found</h1>"},
+		{http.MethodGet, "/api", http.StatusNotFound, `{"error":"not found"}` + "\n"},
+		{http.MethodGet, "/api/posts", http.StatusNotFound, `{"error":"not found"}` + "\n"},
+		{http.MethodGet, "/api/users/7/posts", http.StatusNotFound, `{"error":"not found"}` + "\n"},
+		{http.MethodGet, "/api/admin/roles", http.StatusNotFound, "admin: /api/admin/roles"},
+		{http.MethodPost, "/api/users/7", http.StatusMethodNotAllowed, `{"error":"method not allowed"}` + "\n"},
+		{http.MethodPost, "/", http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed) + "\n"},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, w.Code, tt.code, tt.method, tt.path)
+		assert.Equal(t, w.Body.String(), tt.body, tt.method, tt.path)
+		if tt.code == http.StatusMethodNotAllowed {
+			assert.Equal(t, w.Header().Get("Allow"), "GET, HEAD")
+		}
+	}
+	assert.Equal(t, len(logged), len(tests))
+}
+
+func TestHostFallbacks(t *testing.T) {
+	s := New(0)
+	tenant := s.Host(":tenant.api.example.com")
+	tenant.Get("/users", func(c *Context) error {
+		return c.SendString("users")
+	})
+	assert.NoError(t, tenant.NotFound(func(c *Context) error {
+		return c.Status(http.StatusNotFound).SendString("tenant " + c.Param("tenant") + ": " + c.Path())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://acme.api.example.com/orders", nil)
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Equal(t, w.Body.String(), "tenant acme: /orders")
+
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/orders", nil)
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Equal(t, w.Body.String(), http.StatusText(http.StatusNotFound)+"\n")
+}
@@ -1,6 +1,9 @@
 package server
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+)
 
 type Handler func(req *Request, res *Response) error
 
@@ -25,3 +28,52 @@ func (h Handler) Redirect(url string) Handler {
 		return nil
 	}
 }
+
+// chain composes the middlewares and the handler into a single Handler
+// sharing the same Request and Response. See Request.Next.
+func chain(h Handler, middlewares ...Handler) Handler {
+	if len(middlewares) == 0 {
+		return h
+	}
+	handlers := handlerChain(slices.Concat(middlewares, []Handler{h}))
+	return func(req *Request, res *Response) error {
+		return handlers.serve(0, req, res)
+	}
+}
+
+type handlerChain []Handler
+
+// serve runs the i-th handler of the chain. When it returns without
+// calling Next, the chain goes on unless it failed or sent a response.
+// An error is replied as soon as a handler returns it, so the handlers
+// running before see the final response when Next returns it.
+func (h handlerChain) serve(i int, req *Request, res *Response) error {
+	if i == len(h)-1 {
+		req.next = nil
+		return replyError(req, res, h[i](req, res))
+	}
+	var called bool
+	next := func() error {
+		if called {
+			return nil
+		}
+		called = true
+		return h.serve(i+1, req, res)
+	}
+	req.next = next
+	if err := h[i](req, res); err != nil {
+		return replyError(req, res, err)
+	}
+	if res.Sent() {
+		return nil
+	}
+	return next()
+}
+
+// replyError replies to the request with err, if any, and returns it.
+func replyError(req *Request, res *Response, err error) error {
+	if err != nil {
+		handleError(req, res, err)
+	}
+	return err
+}
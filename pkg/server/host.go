@@ -0,0 +1,132 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Host returns a RouteManager whose routes only match requests sent to
+// the given host, whatever their port. Labels of the host pattern may be
+// params, which are available through Request.Param like path params.
+//
+//	tenant := server.Host(":tenant.api.example.com")
+//	tenant.Get("/users", func(c *i9.Context) error {
+//		return c.SendString(c.Param("tenant"))
+//	})
+func (s *Server) Host(pattern string, middlewares ...any) RouteManager {
+	group := NewRouteGroup(s, "", middlewares...)
+	group.host = hostWithoutPort(pattern)
+	return group
+}
+
+// hostWithoutPort removes the port of a host pattern, such as
+// "localhost:8080", since the port of the requests is not matched.
+func hostWithoutPort(pattern string) string {
+	i := strings.LastIndexByte(pattern, ':')
+	if i <= 0 || strings.Trim(pattern[i+1:], "0123456789") != "" {
+		return pattern
+	}
+	return pattern[:i]
+}
+
+// routeHost binds the route to the given host pattern.
+func routeHost(host string) RouteOption {
+	return func(r *Router) {
+		r.host = host
+	}
+}
+
+// hostTree holds the routes bound to a host pattern.
+type hostTree struct {
+	pattern string
+	labels  []hostLabel
+	root    *node
+}
+
+type hostLabel struct {
+	value      string
+	param      bool
+	constraint constraint
+}
+
+func (rt *router) hostTree(pattern string) *hostTree {
+	for _, ht := range rt.hosts {
+		if ht.pattern == pattern {
+			return ht
+		}
+	}
+	ht := &hostTree{pattern: pattern, root: new(node)}
+	for _, label := range hostLabels(pattern) {
+		name, expr, kind := parseSegment(label)
+		l := hostLabel{value: strings.ToLower(name), param: kind != segmentStatic}
+		if expr != "" {
+			l.constraint = mustCompileConstraint(expr)
+		}
+		ht.labels = append(ht.labels, l)
+	}
+	rt.hosts = append(rt.hosts, ht)
+	return ht
+}
+
+// match reports whether host matches the pattern, returning the values
+// of the host params.
+func (ht *hostTree) match(host string) ([]string, bool) {
+	var values []string
+	for _, label := range ht.labels {
+		value, rest, _ := strings.Cut(host, ".")
+		host = rest
+		switch {
+		case value == "":
+			return nil, false
+		case label.param:
+			if label.constraint != nil && !label.constraint(value) {
+				return nil, false
+			}
+			values = append(values, value)
+		case !strings.EqualFold(label.value, value):
+			return nil, false
+		}
+	}
+	return values, host == ""
+}
+
+func hostLabels(host string) []string {
+	if host == "" {
+		return nil
+	}
+	return strings.Split(host, ".")
+}
+
+// hostParams returns the names of the params of a host pattern.
+func hostParams(host string) []string {
+	var params []string
+	for _, label := range hostLabels(host) {
+		if name, _, kind := parseSegment(label); kind != segmentStatic {
+			params = append(params, name)
+		}
+	}
+	return params
+}
+
+// patternParams returns the names of the host and path params of a pattern.
+func patternParams(pattern string) []string {
+	_, rest := splitPattern(pattern)
+	host, path := splitHost(rest)
+	params := hostParams(host)
+	for _, segment := range pathSegments(path) {
+		if name, _, kind := parseSegment(segment); kind != segmentStatic {
+			params = append(params, name)
+		}
+	}
+	return params
+}
+
+// requestHost returns the host of the request without its port.
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestHost(t *testing.T) {
+	s := New(0)
+	var hostMiddlewareCalls int
+	echo := func(c *Context) error {
+		var params struct {
+			Tenant string `param:"tenant"`
+			ID     int    `param:"id"`
+		}
+		if err := c.ParamsParser(&params); err != nil {
+			return err
+		}
+		return c.SendString(c.Request.HTTP().Pattern + " " + params.Tenant + " " + c.Param("id"))
+	}
+	s.Get("/users", echo)
+	admin := s.Host("admin.example.com")
+	admin.Get("/users", echo)
+	tenant := s.Host(":tenant<alpha>.api.example.com", func(c *Context) error {
+		hostMiddlewareCalls++
+		return nil
+	})
+	tenant.Group("/v1").Get("/users/:id", echo)
+	tenant.Group("/v2").Post("/users", echo)
+
+	tests := []struct {
+		method, host, path string
+		code               int
+		body               string
+	}{
+		{http.MethodGet, "example.com", "/users", http.StatusOK, "GET /users  "},
+		{http.MethodGet, "ADMIN.example.com:8080", "/users", http.StatusOK, "GET admin.example.com/users  "},
+		{http.MethodGet, "acme.api.example.com", "/v1/users/7", http.StatusOK, "GET {tenant<alpha>}.api.example.com/v1/users/{id} acme 7"},
+		{http.MethodPost, "acme.api.example.com", "/v2/users", http.StatusOK, "POST {tenant<alpha>}.api.example.com/v2/users acme "},
+		{http.MethodGet, "acme.api.example.com", "/v2/users", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "acme42.api.example.com", "/v1/users/7", http.StatusNotFound, ""},
+		{http.MethodGet, "example.com", "/v1/users/7", http.StatusNotFound, ""},
+		{http.MethodGet, "a.b.api.example.com", "/v1/users/7", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Host = tt.host
+		w := s.Test().Request(req)
+		assert.Equal(t, w.Code, tt.code, tt.host, tt.path)
+		if tt.code == http.StatusOK {
+			assert.Equal(t, w.Body.String(), tt.body)
+		}
+	}
+	assert.Equal(t, hostMiddlewareCalls, 2)
+
+	routes := s.Routes()
+	assert.Equal(t, routes[1].Host, "admin.example.com")
+	assert.Equal(t, routes[1].Path, "/users")
+}
+
+func TestHostPort(t *testing.T) {
+	s := New(0)
+	s.Host("localhost:8080").Get("/", func(c *Context) error {
+		return c.SendString("local")
+	})
+	s.Host(":tenant.example.com:443").Get("/", func(c *Context) error {
+		return c.SendString(c.Param("tenant"))
+	})
+
+	tests := []struct {
+		url, body string
+	}{
+		{"http://localhost:8080/", "local"},
+		{"http://localhost/", "local"},
+		{"https://acme.example.com:443/", "acme"},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, tt.url, nil))
+		assert.Equal(t, w.Code, http.StatusOK, tt.url)
+		assert.Equal(t, w.Body.String(), tt.body, tt.url)
+	}
+	assert.Equal(t, hostWithoutPort(":tenant.example.com"), ":tenant.example.com")
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// BodyLimit limits the size of the request bodies of the routes to n bytes,
+// replacing the ServerOpts.BodyLimit of the server. A negative n removes
+// the limit. Reading a larger body fails with a *http.MaxBytesError,
+// replied with 413 Request Entity Too Large. The limit applies to the
+// middlewares reading the body as well, and the 413 error is returned
+// to them so they observe that reply.
+//
+// It is set for a single route, or for every route of a group when
+// passed to Group:
+//
+//	server.Post("/avatar", i9.BodyLimit(1<<20), uploadAvatar)
+//	uploads := server.Group("/uploads", i9.BodyLimit(100<<20))
+func BodyLimit(n int64) RouteOption {
+	return func(r *Router) {
+		r.bodyLimit = n
+	}
+}
+
+// routeBodyLimit returns the body size limit of the route,
+// or the one of the server when the route has none.
+func (s *Server) routeBodyLimit(route Router) int64 {
+	if route.bodyLimit != 0 {
+		return route.bodyLimit
+	}
+	return s.bodyLimit
+}
+
+// withBodyLimit makes the handler and the middlewares chained before it
+// read bodies of at most n bytes.
+func withBodyLimit(h Handler, n int64) Handler {
+	return func(req *Request, res *Response) error {
+		if r := req.HTTP(); r.Body != nil {
+			r.Body = http.MaxBytesReader(res.HTTP(), r.Body, n)
+		}
+		return h(req, res)
+	}
+}
+
+// withContentLengthLimit makes the handler fail with the bodies whose
+// Content-Length exceeds n bytes, even when it does not read them.
+func withContentLengthLimit(h Handler, n int64) Handler {
+	return func(req *Request, res *Response) error {
+		if req.HTTP().ContentLength > n {
+			return bodyError(&http.MaxBytesError{Limit: n})
+		}
+		return h(req, res)
+	}
+}
+
+// bodyError returns the error replied when the body could not be
+// decoded: 413 Request Entity Too Large when it exceeds the body
+// limit, or 400 Bad Request.
+func bodyError(err error) *Error {
+	var srvErr *Error
+	if errors.As(err, &srvErr) {
+		return srvErr
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &Error{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Err:        fmt.Errorf("request body larger than %d bytes", maxBytesErr.Limit),
+		}
+	}
+	return &Error{StatusCode: http.StatusBadRequest, Err: err}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestBodyLimit(t *testing.T) {
+	s := New(0, ServerOpts{BodyLimit: 16})
+	var logged []int
+	s.Use(func(c *Context) error {
+		err := c.Next()
+		logged = append(logged, c.StatusCode())
+		return err
+	})
+	handler := func(c *Context) error {
+		var body map[string]any
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	}
+	s.Post("/", handler)
+	s.Post("/unlimited", BodyLimit(-1), handler)
+	uploads := s.Group("/uploads", BodyLimit(32))
+	uploads.Post("/", handler)
+	uploads.Post("/small", BodyLimit(8), handler)
+	uploads.Post("/form", func(c *Context) error {
+		var form struct {
+			Name string `form:"name"`
+		}
+		if err := c.Bind(&form); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	})
+	s.Post("/ignored", func(c *Context) error {
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	body := `{"name":"gopher","team":"go"}` // 29 bytes
+	tests := []struct {
+		path, contentType, body string
+		code                    int
+	}{
+		{"/", "", `{"name":"go"}`, http.StatusNoContent},
+		{"/", "", body, http.StatusRequestEntityTooLarge},
+		{"/unlimited", "", body, http.StatusNoContent},
+		{"/uploads", "", body, http.StatusNoContent},
+		{"/uploads/small", "", `{"name":"go"}`, http.StatusRequestEntityTooLarge},
+		{"/uploads/form", "application/x-www-form-urlencoded", "name=gopher", http.StatusNoContent},
+		{"/uploads/form", "application/x-www-form-urlencoded", "name=" + strings.Repeat("a", 32), http.StatusRequestEntityTooLarge},
+		{"/ignored", "", body, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		for _, chunked := range []bool{false, true} {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if chunked {
+				// Without Content-Length, the limit is enforced while reading.
+				req.ContentLength = -1
+				req.Body = io.NopCloser(req.Body)
+				if tt.path == "/ignored" {
+					tt.code = http.StatusNoContent
+				}
+			}
+			logged = nil
+			w := s.Test().Request(req)
+			assert.Equal(t, w.Code, tt.code, tt.path, tt.body, chunked)
+			assert.Equal(t, len(logged), 1, tt.path, tt.body, chunked)
+		}
+	}
+}
+
+func TestBodyLimitReadByMiddleware(t *testing.T) {
+	s := New(0, ServerOpts{BodyLimit: 16})
+	var status int
+	s.Use(func(c *Context) error {
+		signature := c.Request.Body().Len()
+		err := c.Next()
+		status = c.StatusCode()
+		assert.Equal(t, signature, 0)
+		return err
+	})
+	s.Post("/", func(c *Context) error {
+		var body map[string]any
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+		return c.SendString(string(c.Body()))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"data":"`+strings.Repeat("a", 1000)+`"}`))
+	req.ContentLength = -1
+	req.Body = io.NopCloser(req.Body)
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusRequestEntityTooLarge)
+	assert.Equal(t, status, http.StatusRequestEntityTooLarge)
+}
+
+func TestBodyCache(t *testing.T) {
+	reads := 0
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"gopher"}`))
+	req.Body = readCounter{req.Body, &reads}
+	c := NewContext(req.Context(), req, httptest.NewRecorder())
+
+	assert.Equal(t, c.Request.Body().String(), `{"name":"gopher"}`)
+	assert.Equal(t, string(c.Body()), `{"name":"gopher"}`)
+	var body map[string]string
+	assert.NoError(t, c.BodyParser(&body))
+	assert.Equal(t, body["name"], "gopher")
+	assert.NoError(t, Body(c.Request, &body))
+	b, err := io.ReadAll(c.Request.HTTP().Body)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), `{"name":"gopher"}`)
+	assert.Equal(t, reads, 1)
+}
+
+type readCounter struct {
+	io.ReadCloser
+	reads *int
+}
+
+func (r readCounter) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		*r.reads++
+	}
+	return n, err
+}
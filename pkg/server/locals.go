@@ -0,0 +1,71 @@
+package server
+
+import "context"
+
+// Set stores a value in the request locals, shared by the middlewares
+// and the handler serving the request. Locals are also visible through
+// Request.Context, so they reach the code receiving it.
+//
+//	server.Use(func(c *i9.Context) error {
+//		user, err := authenticate(c.Header("Authorization"))
+//		if err != nil {
+//			return err
+//		}
+//		c.Set(userKey{}, user)
+//		return nil
+//	})
+//
+// Locals are not safe for concurrent use.
+func (r *Request) Set(key, value any) {
+	if r.locals == nil {
+		r.locals = make(map[any]any)
+		r.req = r.req.WithContext(&localsContext{
+			Context: r.req.Context(),
+			locals:  r.locals,
+		})
+	}
+	r.locals[key] = value
+}
+
+// Get returns the value stored in the request locals for key,
+// falling back to the values of the request context.
+func (r *Request) Get(key any) any {
+	if value, ok := r.locals[key]; ok {
+		return value
+	}
+	return r.req.Context().Value(key)
+}
+
+// Locals returns the value stored in the request locals for key.
+// When a value is given, it is stored first, see Set.
+//
+//	c.Locals("tenant", tenant)
+//	tenant := c.Locals("tenant").(string)
+func (r *Request) Locals(key any, value ...any) any {
+	if len(value) > 0 {
+		r.Set(key, value[0])
+	}
+	return r.Get(key)
+}
+
+// Local returns the value stored in the request locals for key,
+// or the zero value of T when it is missing or has another type.
+//
+//	user := i9.Local[*User](c, userKey{})
+func Local[T any](c *Context, key any) T {
+	value, _ := c.Get(key).(T)
+	return value
+}
+
+// localsContext exposes the request locals as context values.
+type localsContext struct {
+	context.Context
+	locals map[any]any
+}
+
+func (c *localsContext) Value(key any) any {
+	if value, ok := c.locals[key]; ok {
+		return value
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestLocals(t *testing.T) {
+	type user struct{ Name string }
+	type userKey struct{}
+	type requestIDKey struct{}
+
+	s := New(0)
+	s.Use(func(req *Request, res *Response) error {
+		req.Set(userKey{}, &user{Name: "gopher"})
+		return nil
+	})
+	s.Use(func(c *Context) error {
+		c.Locals("tenant", "acme")
+		return c.Next()
+	})
+	s.Get("/", func(c *Context) error {
+		assert.Equal(t, Local[*user](c, userKey{}).Name, "gopher")
+		assert.Equal(t, Local[string](c, "tenant"), "acme")
+		assert.Equal(t, Local[int](c, "tenant"), 0)
+		assert.Nil(t, c.Get("missing"))
+		assert.Equal(t, c.Get(requestIDKey{}), "42")
+		return c.SendString(tenantFromContext(c.Request.Context()))
+	})
+	assert.NoError(t, s.Mount("/legacy", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(tenantFromContext(r.Context())))
+	})))
+
+	for _, path := range []string{"/", "/legacy"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), requestIDKey{}, "42"))
+		w := s.Test().Request(req)
+		assert.Equal(t, w.Code, http.StatusOK, path)
+		assert.Equal(t, w.Body.String(), "acme", path)
+	}
+}
+
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value("tenant").(string)
+	return tenant
+}
@@ -3,11 +3,13 @@ package server
 import (
 	"context"
 	"io/fs"
+	"net/http"
 )
 
 // RouteManager defines the interface for managing routes and groups.
 type RouteManager interface {
-	// Use adds middlewares to the server.
+	// Use adds middlewares to the server. On a group, they only apply to
+	// the routes registered afterwards in the group and its nested groups.
 	Use(middlewares ...any) error
 	// Get registers a route for GET requests at the specified endpoint.
 	// Example:
@@ -15,6 +17,15 @@ type RouteManager interface {
 	//	     return c.Send([]byte("Hello World"))
 	//})
 	Get(endpoint string, handlers ...any) error
+	// Head registers a route for HEAD requests at the specified endpoint.
+	// GET routes already answer HEAD requests with an empty body.
+	// Example:
+	//
+	//server.Head("/files/:name", func(c *i9.Context) error {
+	//      c.SetHeader("X-File-Name", c.Param("name"))
+	//      return c.SendStatus(http.StatusOK)
+	//})
+	Head(endpoint string, handlers ...any) error
 	// Post registers a route for POST requests at the specified endpoint.
 	// Example:
 	//
@@ -71,6 +82,49 @@ type RouteManager interface {
 	//	 return c.Send([]byte(msg))
 	//})
 	Delete(endpoint string, handlers ...any) error
+	// Options registers a route for OPTIONS requests at the specified endpoint.
+	// Example:
+	//
+	//server.Options("/hello", func(c *i9.Context) error {
+	//      c.SetHeader("Allow", "GET, OPTIONS")
+	//      return c.SendStatus(http.StatusNoContent)
+	//})
+	Options(endpoint string, handlers ...any) error
+	// Any registers a route for every standard HTTP method at the specified endpoint.
+	// Example:
+	//
+	//server.Any("/echo", func(c *i9.Context) error {
+	//	 return c.SendString(c.Method())
+	//})
+	Any(endpoint string, handlers ...any) error
+	// Match registers a route for each of the given methods at the specified endpoint.
+	// Example:
+	//
+	//server.Match([]string{http.MethodPut, http.MethodPatch}, "/hello/:name", func(c *i9.Context) error {
+	//	 return c.SendString(c.Param("name"))
+	//})
+	Match(methods []string, endpoint string, handlers ...any) error
+	// Mount serves every request under the prefix with the given http.Handler,
+	// stripping the prefix from the request path before calling it.
+	// Example:
+	//
+	//server.Mount("/debug/pprof", http.HandlerFunc(pprof.Index))
+	Mount(prefix string, h http.Handler, middlewares ...any) error
+	// NotFound sets the handler replying to the requests that match no route.
+	// Example:
+	//
+	//server.Group("/api").NotFound(func(c *i9.Context) error {
+	//	 return c.Status(http.StatusNotFound).JSON(i9.JSON{"error": "not found"})
+	//})
+	NotFound(handlers ...any) error
+	// MethodNotAllowed sets the handler replying to the requests whose path
+	// matches a route registered for other methods.
+	// Example:
+	//
+	//server.MethodNotAllowed(func(c *i9.Context) error {
+	//	 return c.Status(http.StatusMethodNotAllowed).SendString("method not allowed")
+	//})
+	MethodNotAllowed(handlers ...any) error
 	// Route registers a route group with the specified pattern.
 	// Example:
 	//
@@ -112,6 +166,14 @@ type Manager interface {
 	//	// Serve embedded files under the root URL pattern "/"
 	//	server.ServeFilesWithFS("/", staticFiles)
 	ServeFilesWithFS(endpoint string, fs fs.FS)
+	// Host returns a RouteManager whose routes only match requests sent to the given host.
+	// Example:
+	//
+	//	tenant := server.Host(":tenant.api.example.com")
+	//	tenant.Get("/users", func(c *i9.Context) error {
+	//		return c.SendString(c.Param("tenant"))
+	//	})
+	Host(pattern string, middlewares ...any) RouteManager
 	// Listen starts the HTTP server, listening on the configured address, and binds all registered routes and middleware.
 	Listen() error
 	// ListenTLS starts the HTTPS server, listening on the configured address, and binds all registered routes and middleware.
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Mount serves every request under prefix, whatever its method, with the
+// given http.Handler after stripping the prefix from the request path.
+// Global middlewares and the optional middlewares run before the handler,
+// which is responsible for its own not found responses.
+//
+//	server.Mount("/debug/pprof", http.HandlerFunc(pprof.Index))
+//
+// A Server can be mounted as well, once its routes are registered:
+//
+//	admin := i9.New(0)
+//	admin.Get("/users", listUsers)
+//	server.Mount("/admin", admin.Handler())
+func (s *Server) Mount(prefix string, h http.Handler, middlewares ...any) error {
+	prefix = strings.TrimSuffix(s.transformPath(prefix), "/")
+	handlers := slices.Concat(middlewares, []any{
+		mountHandler(prefix, h),
+		RouteOption(func(r *Router) {
+			r.handlerName = handlerName(h)
+		}),
+	})
+	endpoints := []string{prefix + "/{mountpath...}"}
+	if prefix != "" {
+		endpoints = []string{prefix, prefix + "/{mountpath...}"}
+	}
+	return s.handle([]string{""}, endpoints, handlers...)
+}
+
+// Mount serves every request under prefix within the group with the given http.Handler.
+func (g *RouteGroup) Mount(prefix string, h http.Handler, middlewares ...any) error {
+	return g.server.Mount(g.fullPath(prefix), h, g.routeHandlers(middlewares...)...)
+}
+
+func mountHandler(prefix string, h http.Handler) Handler {
+	handler := http.StripPrefix(prefix, h)
+	return func(req *Request, res *Response) error {
+		r := req.HTTP()
+		if r.URL.Path == prefix {
+			// The request seen by the middlewares keeps its path.
+			r = r.Clone(r.Context())
+			r.URL.Path += "/"
+			if r.URL.RawPath != "" {
+				r.URL.RawPath += "/"
+			}
+		}
+		handler.ServeHTTP(res.HTTP(), r)
+		return nil
+	}
+}
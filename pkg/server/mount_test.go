@@ -0,0 +1,115 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestMount(t *testing.T) {
+	s := New(0)
+	var globalMiddlewareCalls, mountMiddlewareCalls int
+	s.Use(func(c *Context) error {
+		globalMiddlewareCalls++
+		return nil
+	})
+	s.Get("/", func(c *Context) error {
+		return c.SendString("home")
+	})
+
+	assert.NoError(t, s.Mount("/raw", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Method, " ", r.URL.Path)
+	})))
+
+	admin := New(0)
+	admin.Get("/users", func(c *Context) error {
+		return c.SendString("admin users")
+	})
+	admin.Get("/users/:id", func(c *Context) error {
+		return c.SendString("admin user " + c.Param("id"))
+	})
+	assert.NoError(t, s.Mount("/admin", admin.Handler(), func(c *Context) error {
+		mountMiddlewareCalls++
+		return nil
+	}))
+
+	api := s.Group("/api")
+	assert.NoError(t, api.Mount("/legacy", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "legacy ", r.URL.Path)
+	})))
+
+	tests := []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{http.MethodGet, "/", http.StatusOK, "home"},
+		{http.MethodGet, "/raw", http.StatusOK, "GET /"},
+		{http.MethodGet, "/raw/", http.StatusOK, "GET /"},
+		{http.MethodDelete, "/raw/a/b", http.StatusOK, "DELETE /a/b"},
+		{http.MethodGet, "/admin/users", http.StatusOK, "admin users"},
+		{http.MethodGet, "/admin/users/7", http.StatusOK, "admin user 7"},
+		{http.MethodGet, "/admin/unknown", http.StatusNotFound, ""},
+		{http.MethodPost, "/admin/users", http.StatusMethodNotAllowed, ""},
+		{http.MethodPut, "/api/legacy/orders", http.StatusOK, "legacy /orders"},
+		{http.MethodGet, "/rawdata", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, w.Code, tt.code, tt.method, tt.path)
+		if tt.code == http.StatusOK {
+			assert.Equal(t, w.Body.String(), tt.body)
+		}
+	}
+	assert.Equal(t, mountMiddlewareCalls, 4)
+	assert.Equal(t, globalMiddlewareCalls, len(tests))
+
+	routes := s.Routes()
+	assert.Equal(t, routes[1].Method, "")
+	assert.Equal(t, routes[1].Path, "/raw")
+	assert.Equal(t, routes[2].Path, "/raw/{mountpath...}")
+}
+
+func TestMountWithCors(t *testing.T) {
+	s := New(0)
+	Cors(s)
+	assert.NoError(t, s.Mount("/raw", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "raw ", r.URL.Path)
+	})))
+	s.Get("/after", func(c *Context) error {
+		return c.SendString("after")
+	})
+
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/after", nil))
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), "after")
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodOptions, "/after", nil))
+	assert.Equal(t, w.Code, http.StatusNoContent)
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Origin"), "*")
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodGet, "/raw/files", nil))
+	assert.Equal(t, w.Body.String(), "raw /files")
+}
+
+func TestMountKeepsPath(t *testing.T) {
+	s := New(0)
+	var paths []string
+	s.Use(func(c *Context) error {
+		err := c.Next()
+		paths = append(paths, c.Path())
+		return err
+	})
+	assert.NoError(t, s.Mount("/raw", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.URL.Path)
+	})))
+
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/raw", nil))
+	assert.Equal(t, w.Body.String(), "/")
+	w = s.Test().Request(httptest.NewRequest(http.MethodGet, "/raw/a", nil))
+	assert.Equal(t, w.Body.String(), "/a")
+	assert.Equal(t, paths, []string{"/raw", "/raw/a"})
+}
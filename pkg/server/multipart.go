@@ -0,0 +1,324 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// MultipartOptions configures how multipart forms are read by
+// MultipartForm, FormFile, FormFiles, MultipartParts, BodyParser and Bind.
+// They are set for every route with ServerOpts.Multipart, or for a single
+// route or group with the Multipart option.
+type MultipartOptions struct {
+	// MaxMemory is the number of bytes of the form kept in memory, the
+	// rest of the files being stored in temporary files on disk. The
+	// BodyLimit of the route bounds the size stored on disk.
+	// Defaults to 32 MB.
+	MaxMemory int64
+	// MaxFileSize limits the size of every file to a number of bytes.
+	// Larger files are replied with 413 Request Entity Too Large.
+	// Zero means no limit.
+	MaxFileSize int64
+	// AllowedTypes lists the media types accepted for the files, such
+	// as "application/pdf" or "image/*", matched against the type
+	// sniffed from their first bytes with http.DetectContentType.
+	// Other files are replied with 415 Unsupported Media Type.
+	// Empty accepts every type.
+	AllowedTypes []string
+}
+
+// Multipart sets the MultipartOptions of the route, replacing the
+// ones of the server.
+//
+//	server.Post("/avatar", i9.Multipart(i9.MultipartOptions{
+//		MaxFileSize:  1 << 20,
+//		AllowedTypes: []string{"image/png", "image/jpeg"},
+//	}), uploadAvatar)
+func Multipart(opts MultipartOptions) RouteOption {
+	return func(r *Router) {
+		r.multipart = &opts
+	}
+}
+
+// withMultipart makes the handler read multipart forms with the options,
+// or with the ones of the server when nil, and removes the temporary
+// files of the form once it returns.
+func withMultipart(h Handler, opts *MultipartOptions) Handler {
+	return func(req *Request, res *Response) error {
+		req.multipart = opts
+		defer req.removeMultipartFiles()
+		return h(req, res)
+	}
+}
+
+// removeMultipartFiles removes the temporary files
+// of the multipart form stored on disk.
+func (r *Request) removeMultipartFiles() {
+	if form := r.req.MultipartForm; form != nil {
+		form.RemoveAll()
+	}
+}
+
+// multipartOptions returns the MultipartOptions of the route handling
+// the request, or the ones of the server.
+func (r *Request) multipartOptions() MultipartOptions {
+	opts := MultipartOptions{}
+	if r.multipart != nil {
+		opts = *r.multipart
+	} else if s, ok := serverFromContext(r.Context()); ok {
+		opts = s.multipart
+	}
+	if opts.MaxMemory <= 0 {
+		opts.MaxMemory = defaultMaxMemory
+	}
+	return opts
+}
+
+// MultipartForm parses the multipart form sent as body and checks its
+// files against the MultipartOptions of the route. The form is parsed
+// once and returned again by the next calls.
+func (c *Context) MultipartForm() (*multipart.Form, error) {
+	return c.Request.multipartForm()
+}
+
+func (r *Request) multipartForm() (*multipart.Form, error) {
+	if r.multipartParsed {
+		return r.req.MultipartForm, r.multipartErr
+	}
+	r.multipartParsed = true
+	opts := r.multipartOptions()
+	if err := r.req.ParseMultipartForm(opts.MaxMemory); err != nil {
+		r.multipartErr = multipartError(err)
+		return nil, r.multipartErr
+	}
+	for _, files := range r.req.MultipartForm.File {
+		for _, fh := range files {
+			if err := checkFile(fh, opts); err != nil {
+				r.multipartErr = err
+				return nil, err
+			}
+		}
+	}
+	return r.req.MultipartForm, nil
+}
+
+// multipartError returns the error replied when the multipart form
+// could not be read.
+func multipartError(err error) error {
+	if errors.Is(err, http.ErrNotMultipart) {
+		return &Error{StatusCode: http.StatusUnsupportedMediaType, Err: err}
+	}
+	return bodyError(err)
+}
+
+// FormFile returns the first file sent with the key in the multipart form.
+// It fails with http.ErrMissingFile, replied with 400 Bad Request, when
+// there is none.
+func (c *Context) FormFile(key string) (*multipart.FileHeader, error) {
+	files, err := c.FormFiles(key)
+	if err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+// FormFiles returns the files sent with the key in the multipart form.
+// It fails with http.ErrMissingFile, replied with 400 Bad Request, when
+// there is none.
+//
+//	files, err := c.FormFiles("photos")
+//	if err != nil {
+//		return err
+//	}
+//	for _, fh := range files {
+//		if err := c.SaveFile(fh, filepath.Join("uploads", filepath.Base(fh.Filename))); err != nil {
+//			return err
+//		}
+//	}
+func (c *Context) FormFiles(key string) ([]*multipart.FileHeader, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+	files := form.File[key]
+	if len(files) == 0 {
+		return nil, &Error{StatusCode: http.StatusBadRequest, Err: http.ErrMissingFile}
+	}
+	return files, nil
+}
+
+// SaveFile writes the content of the uploaded file to path, replacing
+// the file found there. The file name sent by the client must not be
+// used as path without sanitizing it, such as with filepath.Base.
+func (c *Context) SaveFile(fh *multipart.FileHeader, path string) error {
+	src, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return err
+	}
+	return dst.Close()
+}
+
+// checkFile checks the size and the sniffed media type of the file.
+func checkFile(fh *multipart.FileHeader, opts MultipartOptions) error {
+	if opts.MaxFileSize > 0 && fh.Size > opts.MaxFileSize {
+		return fileTooLarge(fh.Filename, opts.MaxFileSize)
+	}
+	if len(opts.AllowedTypes) == 0 {
+		return nil
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	return checkFileType(fh.Filename, http.DetectContentType(head[:n]), opts.AllowedTypes)
+}
+
+// sniffLen is the number of bytes read by http.DetectContentType.
+const sniffLen = 512
+
+func checkFileType(name, contentType string, allowed []string) error {
+	for _, t := range allowed {
+		if matchMediaType(mediaType(t), contentType) >= 0 {
+			return nil
+		}
+	}
+	return &Error{
+		StatusCode: http.StatusUnsupportedMediaType,
+		Err:        fmt.Errorf("file %q has unsupported type %q", name, mediaType(contentType)),
+	}
+}
+
+func fileTooLarge(name string, limit int64) error {
+	return &Error{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Err:        fmt.Errorf("file %q larger than %d bytes", name, limit),
+	}
+}
+
+// Part is a part of a multipart body, read as it arrives.
+type Part struct {
+	*multipart.Part
+	r           io.Reader
+	contentType string
+}
+
+// Read reads the content of the part. For files, it fails once
+// the MaxFileSize of the route is exceeded.
+func (p *Part) Read(b []byte) (int, error) {
+	return p.r.Read(b)
+}
+
+// ContentType returns the media type sniffed from the first bytes of
+// the part with http.DetectContentType.
+func (p *Part) ContentType() string {
+	return p.contentType
+}
+
+// MultipartParts returns the parts of the multipart body one by one as
+// they arrive, without storing them in memory or on disk, to process
+// huge uploads. Each part must be read before moving on to the next one.
+// The files are checked against the MultipartOptions of the route: an
+// error is yielded with a file of a type which is not allowed, and
+// reading a file larger than MaxFileSize fails. The iteration stops at
+// the first error.
+//
+//	for part, err := range c.MultipartParts() {
+//		if err != nil {
+//			return err
+//		}
+//		if part.FileName() != "" {
+//			_, err = io.Copy(storage.Writer(part.FileName()), part)
+//		}
+//	}
+//
+// The body must be read either as parts or as a form with MultipartForm.
+func (c *Context) MultipartParts() iter.Seq2[*Part, error] {
+	return func(yield func(*Part, error) bool) {
+		opts := c.Request.multipartOptions()
+		mr, err := c.Request.HTTP().MultipartReader()
+		if err != nil {
+			yield(nil, multipartError(err))
+			return
+		}
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, bodyError(err))
+				return
+			}
+			part, err := newPart(p, opts)
+			if !yield(part, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+func newPart(p *multipart.Part, opts MultipartOptions) (*Part, error) {
+	br := bufio.NewReaderSize(p, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, bodyError(err)
+	}
+	part := &Part{Part: p, r: br, contentType: http.DetectContentType(head)}
+	if p.FileName() == "" {
+		return part, nil
+	}
+	if opts.MaxFileSize > 0 {
+		part.r = &fileLimitReader{r: br, name: p.FileName(), limit: opts.MaxFileSize}
+	}
+	if len(opts.AllowedTypes) > 0 {
+		if err := checkFileType(p.FileName(), part.contentType, opts.AllowedTypes); err != nil {
+			return part, err
+		}
+	}
+	return part, nil
+}
+
+// fileLimitReader fails once more than limit bytes of the file are read,
+// returning the bytes up to the limit and then only the error.
+type fileLimitReader struct {
+	r     io.Reader
+	name  string
+	limit int64
+	read  int64
+	err   error
+}
+
+func (l *fileLimitReader) Read(b []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	n, err := l.r.Read(b)
+	l.read += int64(n)
+	if over := l.read - l.limit; over > 0 {
+		l.err = fileTooLarge(l.name, l.limit)
+		return n - int(over), l.err
+	}
+	return n, err
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type formFile struct {
+	key, name string
+	content   []byte
+}
+
+func newMultipartRequest(t *testing.T, path string, fields map[string]string, files ...formFile) *http.Request {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for key, value := range fields {
+		assert.NoError(t, w.WriteField(key, value))
+	}
+	for _, f := range files {
+		fw, err := w.CreateFormFile(f.key, f.name)
+		assert.NoError(t, err)
+		_, err = fw.Write(f.content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	req := httptest.NewRequest(http.MethodPost, path, body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestMultipartForm(t *testing.T) {
+	dir := t.TempDir()
+	s := New(0, ServerOpts{Multipart: MultipartOptions{MaxFileSize: 64}})
+	s.Post("/photos", func(c *Context) error {
+		var form struct {
+			Album string `form:"album"`
+		}
+		if err := c.Bind(&form); err != nil {
+			return err
+		}
+		files, err := c.FormFiles("photo")
+		if err != nil {
+			return err
+		}
+		for _, fh := range files {
+			if err := c.SaveFile(fh, filepath.Join(dir, form.Album+"-"+filepath.Base(fh.Filename))); err != nil {
+				return err
+			}
+		}
+		return c.JSON(JSON{"saved": len(files)})
+	})
+	s.Post("/avatar", Multipart(MultipartOptions{MaxMemory: 1, AllowedTypes: []string{"image/*"}}), func(c *Context) error {
+		fh, err := c.FormFile("avatar")
+		if err != nil {
+			return err
+		}
+		return c.SaveFile(fh, filepath.Join(dir, "avatar.png"))
+	})
+
+	png := append(pngHeader, "pixels"...)
+	tests := []struct {
+		req  *http.Request
+		code int
+	}{
+		{newMultipartRequest(t, "/photos", map[string]string{"album": "trip"},
+			formFile{"photo", "a.png", png}, formFile{"photo", "../b.txt", []byte("hello")}), http.StatusOK},
+		{newMultipartRequest(t, "/photos", nil, formFile{"photo", "big.txt", bytes.Repeat([]byte("a"), 65)}), http.StatusRequestEntityTooLarge},
+		{newMultipartRequest(t, "/photos", map[string]string{"album": "trip"}), http.StatusBadRequest},
+		{httptest.NewRequest(http.MethodPost, "/photos", strings.NewReader(`{"album":"trip"}`)), http.StatusUnsupportedMediaType},
+		{newMultipartRequest(t, "/avatar", nil, formFile{"avatar", "me.png", png}), http.StatusOK},
+		{newMultipartRequest(t, "/avatar", nil, formFile{"avatar", "me.png", []byte("<html></html>")}), http.StatusUnsupportedMediaType},
+	}
+	for i, tt := range tests {
+		w := s.Test().Request(tt.req)
+		assert.Equal(t, w.Code, tt.code, i, w.Body.String())
+	}
+
+	for name, content := range map[string][]byte{"trip-a.png": png, "trip-b.txt": []byte("hello"), "avatar.png": png} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		assert.Equal(t, b, content, name)
+	}
+}
+
+func TestMultipartParts(t *testing.T) {
+	s := New(0)
+	s.Post("/upload", Multipart(MultipartOptions{MaxFileSize: 16, AllowedTypes: []string{"text/plain"}}), func(c *Context) error {
+		var received []string
+		for part, err := range c.MultipartParts() {
+			if err != nil {
+				return err
+			}
+			b, err := io.ReadAll(part)
+			if err != nil {
+				return err
+			}
+			received = append(received, part.FormName()+"="+string(b)+" "+mediaType(part.ContentType()))
+		}
+		return c.SendString(strings.Join(received, ","))
+	})
+
+	req := newMultipartRequest(t, "/upload", map[string]string{"title": "notes"}, formFile{"file", "notes.txt", []byte("hello")})
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), "title=notes text/plain,file=hello text/plain")
+
+	req = newMultipartRequest(t, "/upload", nil, formFile{"file", "notes.txt", bytes.Repeat([]byte("a"), 17)})
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusRequestEntityTooLarge)
+
+	req = newMultipartRequest(t, "/upload", nil, formFile{"file", "image.png", pngHeader})
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusUnsupportedMediaType)
+
+	req = httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("title=notes"))
+	c := NewContext(req.Context(), req, httptest.NewRecorder())
+	for _, err := range c.MultipartParts() {
+		assert.True(t, errors.Is(err, http.ErrNotMultipart))
+	}
+}
+
+func TestMultipartTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	s := New(0, ServerOpts{Multipart: MultipartOptions{MaxMemory: 1, MaxFileSize: 64, AllowedTypes: []string{"text/plain"}}})
+	s.Post("/upload", func(c *Context) error {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, entries)
+		return c.SendString(fh.Filename)
+	})
+
+	tests := []struct {
+		file formFile
+		code int
+	}{
+		{formFile{"file", "notes.txt", []byte("hello")}, http.StatusOK},
+		{formFile{"file", "big.txt", bytes.Repeat([]byte("a"), 65)}, http.StatusRequestEntityTooLarge},
+		{formFile{"file", "image.png", pngHeader}, http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(newMultipartRequest(t, "/upload", nil, tt.file))
+		assert.Equal(t, w.Code, tt.code, tt.file.name)
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Empty(t, entries, tt.file.name)
+	}
+}
+
+func TestFileLimitReader(t *testing.T) {
+	l := &fileLimitReader{r: strings.NewReader("0123456789"), name: "digits.txt", limit: 4}
+	b := make([]byte, 3)
+	n, err := l.Read(b)
+	assert.Equal(t, n, 3)
+	assert.NoError(t, err)
+	n, err = l.Read(b)
+	assert.Equal(t, n, 1)
+	assert.Equal(t, string(b[:n]), "3")
+	var srvErr *Error
+	assert.True(t, errors.As(err, &srvErr))
+	assert.Equal(t, srvErr.StatusCode, http.StatusRequestEntityTooLarge)
+	for range 2 {
+		n, err = l.Read(b)
+		assert.Equal(t, n, 0)
+		assert.Equal(t, err, error(srvErr))
+	}
+}
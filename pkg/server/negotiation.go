@@ -0,0 +1,219 @@
+package server
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Accepts returns the offer preferred by the Accept header of the request,
+// or an empty string when none is acceptable. Offers may be media types
+// or extensions such as "json" or "html".
+// Without Accept header, the first offer is returned.
+//
+//	switch c.Accepts("json", "html") {
+//	case "json":
+//		return c.JSON(users)
+//	case "html":
+//		return c.SendFile("users.html")
+//	}
+func (r *Request) Accepts(offers ...string) string {
+	return negotiate(r.Header("Accept"), offers, matchMediaType)
+}
+
+// AcceptsEncodings returns the offer preferred by the Accept-Encoding
+// header of the request, or an empty string when none is acceptable.
+// The identity encoding is acceptable unless the header excludes it.
+func (r *Request) AcceptsEncodings(offers ...string) string {
+	header := r.Header("Accept-Encoding")
+	if header != "" && !strings.Contains(header, "identity") && !strings.Contains(header, "*") {
+		header += ", identity;q=0.001"
+	}
+	return negotiate(header, offers, matchToken)
+}
+
+// AcceptsLanguages returns the offer preferred by the Accept-Language
+// header of the request, or an empty string when none is acceptable.
+// A language range such as "en" also matches "en-US".
+func (r *Request) AcceptsLanguages(offers ...string) string {
+	return negotiate(r.Header("Accept-Language"), offers, matchLanguage)
+}
+
+// Format calls the function registered for the media type preferred by
+// the Accept header of the request. Keys are media types or extensions
+// such as "json", "html", "xml" or "text", and a "default" key handles
+// the requests accepting none of them. Otherwise Format returns a 406
+// Not Acceptable error.
+//
+//	return c.Format(map[string]func() error{
+//		"json": func() error { return c.JSON(user) },
+//		"html": func() error { return c.SendString("<h1>" + user.Name + "</h1>") },
+//	})
+//
+// When several types are equally acceptable, json is preferred over
+// html, xml and text, followed by the other keys in alphabetical order.
+func (c *Context) Format(handlers map[string]func() error) error {
+	c.Response.HTTP().Header().Add("Vary", "Accept")
+	offers := make([]string, 0, len(handlers))
+	for offer := range handlers {
+		if offer != "default" {
+			offers = append(offers, offer)
+		}
+	}
+	slices.SortFunc(offers, func(a, b string) int {
+		if i, j := formatPriority(a), formatPriority(b); i != j {
+			return i - j
+		}
+		return strings.Compare(a, b)
+	})
+	if offer := c.Accepts(offers...); offer != "" {
+		return handlers[offer]()
+	}
+	if handler, ok := handlers["default"]; ok {
+		return handler()
+	}
+	return &Error{
+		StatusCode: http.StatusNotAcceptable,
+		Err:        errors.New(http.StatusText(http.StatusNotAcceptable)),
+	}
+}
+
+// formats maps the extensions accepted as offers to their media type,
+// in order of preference.
+var formats = []struct{ ext, mediaType string }{
+	{"json", "application/json"},
+	{"html", "text/html"},
+	{"xml", "application/xml"},
+	{"text", "text/plain"},
+	{"txt", "text/plain"},
+}
+
+func formatPriority(offer string) int {
+	for i, f := range formats {
+		if f.ext == offer || f.mediaType == offer {
+			return i
+		}
+	}
+	return len(formats)
+}
+
+// mediaType returns the media type of an offer, without parameters.
+func mediaType(offer string) string {
+	if !strings.Contains(offer, "/") {
+		for _, f := range formats {
+			if f.ext == offer {
+				return f.mediaType
+			}
+		}
+		offer = mime.TypeByExtension("." + offer)
+	}
+	offer, _, _ = strings.Cut(offer, ";")
+	return strings.ToLower(strings.TrimSpace(offer))
+}
+
+type acceptRange struct {
+	value string
+	q     float64
+}
+
+// parseAccept parses the comma separated ranges of an Accept* header
+// with their quality values.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for part := range strings.SplitSeq(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		r := acceptRange{value: value, q: 1}
+		for param := range strings.SplitSeq(params, ";") {
+			key, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if q, err := strconv.ParseFloat(v, 64); err == nil && q >= 0 && q <= 1 {
+					r.q = q
+				}
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// negotiate returns the offer with the highest quality according to the
+// header. The quality of an offer is given by the most specific range
+// matching it, ties being broken by the order of the ranges and then by
+// the order of the offers.
+func negotiate(header string, offers []string, match func(rng, offer string) int) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	ranges := parseAccept(header)
+	if len(ranges) == 0 {
+		return offers[0]
+	}
+	best, bestQ, bestIndex := "", 0.0, len(ranges)
+	for _, offer := range offers {
+		q, index, specificity := 0.0, -1, -1
+		for i, r := range ranges {
+			if s := match(r.value, offer); s > specificity {
+				q, index, specificity = r.q, i, s
+			}
+		}
+		if index < 0 || q == 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && index < bestIndex) {
+			best, bestQ, bestIndex = offer, q, index
+		}
+	}
+	return best
+}
+
+// matchMediaType returns the specificity of the media range matching
+// the offer, or -1 when it does not match.
+func matchMediaType(rng, offer string) int {
+	offer = mediaType(offer)
+	if rng == "*" {
+		rng = "*/*"
+	}
+	rngType, rngSub, _ := strings.Cut(rng, "/")
+	offerType, _, _ := strings.Cut(offer, "/")
+	switch {
+	case offer == "":
+		return -1
+	case rng == offer:
+		return 2
+	case rngType == offerType && rngSub == "*":
+		return 1
+	case rng == "*/*":
+		return 0
+	}
+	return -1
+}
+
+func matchToken(rng, offer string) int {
+	switch {
+	case strings.EqualFold(rng, offer):
+		return 1
+	case rng == "*":
+		return 0
+	}
+	return -1
+}
+
+func matchLanguage(rng, offer string) int {
+	offer = strings.ToLower(offer)
+	switch {
+	case rng == offer:
+		return 2
+	case strings.HasPrefix(offer, rng+"-"), strings.HasPrefix(rng, offer+"-"):
+		return 1
+	case rng == "*":
+		return 0
+	}
+	return -1
+}
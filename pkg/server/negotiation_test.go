@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestAccepts(t *testing.T) {
+	browser := "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	tests := []struct {
+		header string
+		offers []string
+		want   string
+	}{
+		{"", []string{"json", "html"}, "json"},
+		{"application/json", []string{"html", "json"}, "json"},
+		{browser, []string{"json", "html"}, "html"},
+		{browser, []string{"json", "xml"}, "xml"},
+		{browser, []string{"application/json"}, "application/json"},
+		{"text/*;q=0.5, application/json;q=0.4", []string{"json", "text"}, "text"},
+		{"text/plain;q=0, text/*", []string{"text/plain", "text/csv"}, "text/csv"},
+		{"image/png", []string{"json", "html"}, ""},
+		{"*", []string{"html"}, "html"},
+		{"application/json;q=2", []string{"html", "json"}, "json"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", tt.header)
+		r := NewRequest(req)
+		assert.Equal(t, r.Accepts(tt.offers...), tt.want, tt.header)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0.5, br")
+	req.Header.Set("Accept-Language", "pt-BR, en;q=0.8, *;q=0.1")
+	r := NewRequest(req)
+	assert.Equal(t, r.AcceptsEncodings("gzip", "br"), "br")
+	assert.Equal(t, r.AcceptsEncodings("zstd", "identity"), "identity")
+	assert.Equal(t, r.AcceptsEncodings("zstd"), "")
+	assert.Equal(t, r.AcceptsLanguages("en-US", "pt"), "pt")
+	assert.Equal(t, r.AcceptsLanguages("es", "en-GB"), "en-GB")
+	assert.Equal(t, r.AcceptsLanguages("es"), "es")
+
+	req.Header.Set("Accept-Encoding", "gzip, identity;q=0")
+	assert.Equal(t, r.AcceptsEncodings("identity"), "")
+}
+
+func TestContextFormat(t *testing.T) {
+	s := New(0)
+	s.Get("/users/:id", func(c *Context) error {
+		return c.Format(map[string]func() error{
+			"json": func() error { return c.JSON(JSON{"id": c.Param("id")}) },
+			"html": func() error { return c.SendString("<h1>" + c.Param("id") + "</h1>") },
+			"text": func() error { return c.SendString("user " + c.Param("id")) },
+		})
+	})
+	s.Get("/reports", func(c *Context) error {
+		return c.Format(map[string]func() error{
+			"text/csv": func() error { return c.SendString("id\n1") },
+			"default": func() error {
+				return &Error{StatusCode: http.StatusBadRequest, Err: errors.New("csv only")}
+			},
+		})
+	})
+
+	tests := []struct {
+		path, accept string
+		code         int
+		contentType  string
+		body         string
+	}{
+		{"/users/7", "", http.StatusOK, "application/json", `{"id":"7"}` + "\n"},
+		{"/users/7", "*/*", http.StatusOK, "application/json", `{"id":"7"}` + "\n"},
+		{"/users/7", "text/html,*/*;q=0.8", http.StatusOK, "text/html; charset=utf-8", "<h1>7</h1>"},
+		{"/users/7", "text/plain", http.StatusOK, "text/plain; charset=utf-8", "user 7"},
+		{"/users/7", "image/png", http.StatusNotAcceptable, "text/plain; charset=utf-8", "Not Acceptable\n"},
+		{"/users/7", "image/png, application/json;q=0.1", http.StatusOK, "application/json", `{"id":"7"}` + "\n"},
+		{"/reports", "application/json", http.StatusBadRequest, "application/json", `{"err":"csv only"}`},
+		{"/reports", "text/csv", http.StatusOK, "text/plain; charset=utf-8", "id\n1"},
+		{"/missing", "application/json", http.StatusNotFound, "application/json", `{"err":"Not Found"}`},
+		{"/missing", "application/xml", http.StatusNotFound, "application/xml; charset=utf-8", xml.Header + "<response><err>Not Found</err></response>"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("Accept", tt.accept)
+		w := s.Test().Request(req)
+		assert.Equal(t, w.Code, tt.code, tt.path, tt.accept)
+		assert.Equal(t, w.Header().Get("Content-Type"), tt.contentType, tt.path, tt.accept)
+		assert.Equal(t, w.Body.String(), tt.body, tt.path, tt.accept)
+	}
+}
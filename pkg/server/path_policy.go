@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// PathPolicy defines how the router handles request paths that only
+// match a route once cleaned, such as `/users/`, `/users//1` or
+// `/users/./1`.
+type PathPolicy int
+
+const (
+	// PathStrict matches the request path exactly as received.
+	PathStrict PathPolicy = iota
+	// PathRedirect redirects to the canonical path, using 301 for GET and
+	// HEAD requests and 308 for the other methods so the body is kept.
+	PathRedirect
+	// PathNormalize serves the canonical path transparently, rewriting
+	// the request URL before the handler runs.
+	PathNormalize
+)
+
+// canonical returns the canonical form of the escaped path when it differs
+// from the request path and matches a registered route. Repeated slashes and
+// dot segments are always cleaned, while the trailing slash is only added
+// or removed when the request path itself does not match any route.
+func (rt *router) canonical(method, host, escapedPath string, matched bool) (string, bool) {
+	cleaned := cleanPath(escapedPath)
+	if cleaned != escapedPath && rt.matches(method, host, cleaned) {
+		return cleaned, true
+	}
+	if matched || len(rt.allowed(host, escapedPath)) > 0 {
+		return "", false
+	}
+	toggled := toggleTrailingSlash(cleaned)
+	if toggled != escapedPath && rt.matches(method, host, toggled) {
+		return toggled, true
+	}
+	return "", false
+}
+
+// matches reports whether a route is registered for the path,
+// regardless of whether it accepts the method.
+func (rt *router) matches(method, host, escapedPath string) bool {
+	if _, _, ok := rt.lookup(method, host, escapedPath); ok {
+		return true
+	}
+	return len(rt.allowed(host, escapedPath)) > 0
+}
+
+// cleanPath collapses repeated slashes and resolves dot segments,
+// keeping the trailing slash of the original path.
+func cleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	if p[0] != '/' {
+		p = "/" + p
+	}
+	cleaned := path.Clean(p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+func toggleTrailingSlash(p string) string {
+	if p == "/" {
+		return p
+	}
+	if trimmed, ok := strings.CutSuffix(p, "/"); ok {
+		return trimmed
+	}
+	return p + "/"
+}
+
+func redirectToCanonical(w http.ResponseWriter, r *http.Request, escapedPath string) {
+	code := http.StatusPermanentRedirect
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		code = http.StatusMovedPermanently
+	}
+	location := escapedPath
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+	w.Header().Set("Location", location)
+	w.WriteHeader(code)
+}
+
+func setPath(u *url.URL, escapedPath string) {
+	unescaped, err := url.PathUnescape(escapedPath)
+	if err != nil {
+		return
+	}
+	u.Path = unescaped
+	u.RawPath = escapedPath
+}
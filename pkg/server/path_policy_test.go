@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func newPolicyServer(t *testing.T, opts ServerOpts) *Server {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "static"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "static", "app.css"), []byte("body{}"), 0644))
+
+	s := New(0, opts)
+	echo := func(c *Context) error {
+		return c.SendString(c.Path() + " " + c.Param("name"))
+	}
+	s.Get("/users", echo)
+	s.Post("/users", echo)
+	s.Group("/api").Get("/docs/", echo)
+	s.Get("/hello/:name", echo)
+	s.ServeFiles("/static", dir)
+	return s
+}
+
+func TestPathPolicyStrict(t *testing.T) {
+	s := newPolicyServer(t, ServerOpts{})
+	for _, path := range []string{"/users/", "//users", "/api/docs", "/USERS"} {
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, w.Code, http.StatusNotFound, path)
+	}
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/hello/a%2Fb", nil))
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), "/hello/a/b a/b")
+}
+
+func TestPathPolicyRedirect(t *testing.T) {
+	s := newPolicyServer(t, ServerOpts{PathPolicy: PathRedirect})
+	tests := []struct {
+		method, path string
+		code         int
+		location     string
+	}{
+		{http.MethodGet, "/users/?page=2", http.StatusMovedPermanently, "/users?page=2"},
+		{http.MethodPost, "/users/", http.StatusPermanentRedirect, "/users"},
+		{http.MethodGet, "/api/docs", http.StatusMovedPermanently, "/api/docs/"},
+		{http.MethodGet, "/hello/../users", http.StatusMovedPermanently, "/users"},
+		{http.MethodGet, "/hello//gopher", http.StatusMovedPermanently, "/hello/gopher"},
+		{http.MethodGet, "/static//app.css", http.StatusMovedPermanently, "/static/app.css"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/", nil)
+		req.URL.Path, req.URL.RawQuery, _ = strings.Cut(tt.path, "?")
+		w := s.Test().Request(req)
+		assert.Equal(t, w.Code, tt.code, tt.path)
+		assert.Equal(t, w.Header().Get("Location"), tt.location, tt.path)
+	}
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/unknown/", nil))
+	assert.Equal(t, w.Code, http.StatusNotFound)
+}
+
+func TestPathPolicyNormalize(t *testing.T) {
+	s := newPolicyServer(t, ServerOpts{PathPolicy: PathNormalize, CaseInsensitive: true})
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/users/", "/users "},
+		{"/api/docs", "/api/docs/ "},
+		{"/hello//Gopher", "/hello/Gopher Gopher"},
+		{"/HELLO/Gopher", "/HELLO/Gopher Gopher"},
+		{"/static//app.css", "body{}"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = tt.path
+		w := s.Test().Request(req)
+		assert.Equal(t, w.Code, http.StatusOK, tt.path)
+		assert.Equal(t, w.Body.String(), tt.body, tt.path)
+	}
+	w := s.Test().Request(httptest.NewRequest(http.MethodDelete, "/users/", nil))
+	assert.Equal(t, w.Code, http.StatusMethodNotAllowed)
+}
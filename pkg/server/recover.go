@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoverConfig configures how panics raised by handlers are recovered.
+type RecoverConfig struct {
+	// DisableStack skips capturing the stack trace of the panic.
+	DisableStack bool
+	// OnPanic is called with the recovered panic before the error
+	// handler replies, e.g. to report it. Defaults to logging the panic
+	// and its stack trace.
+	OnPanic func(c *Context, err *PanicError)
+}
+
+// PanicError is the error passed to the ErrorHandler when a handler panics.
+type PanicError struct {
+	// Value is the value given to panic.
+	Value any
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Recover returns a middleware recovering from the panics raised by the
+// middlewares and handlers running after it. The panic is returned as a
+// *PanicError to the middlewares running before it and reported to the
+// ServerOpts.ErrorHandler, which by default replies with 500 Internal
+// Server Error.
+//
+//	server.Use(i9.Recover(i9.RecoverConfig{
+//		OnPanic: func(c *i9.Context, err *i9.PanicError) {
+//			sentry.CaptureException(err)
+//		},
+//	}))
+//
+// Panics with http.ErrAbortHandler are not recovered so the request is
+// aborted as usual. Global middlewares run after the ones of the routes
+// and groups, so recovery of every request, middlewares included, is
+// enabled with ServerOpts.Recover instead.
+func Recover(config ...RecoverConfig) HandlerWithContext {
+	var cfg RecoverConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	return func(c *Context) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = cfg.panicError(c, v)
+			}
+		}()
+		return c.Next()
+	}
+}
+
+// panicError reports the recovered panic and returns
+// the *PanicError replied to the request.
+func (cfg *RecoverConfig) panicError(c *Context, v any) error {
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+	err := &PanicError{Value: v}
+	if !cfg.DisableStack {
+		err.Stack = debug.Stack()
+	}
+	if cfg.OnPanic != nil {
+		cfg.OnPanic(c, err)
+	} else {
+		log.Printf("%s %s: %v\n%s", c.Method(), c.Path(), err, err.Stack)
+	}
+	return err
+}
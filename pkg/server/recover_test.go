@@ -0,0 +1,101 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestRecover(t *testing.T) {
+	var reported []*PanicError
+	var downstream error
+	s := New(0)
+	s.Get("/unprotected", func(c *Context) error {
+		panic("unprotected")
+	})
+	logger := func(c *Context) error {
+		downstream = c.Next()
+		return downstream
+	}
+	api := s.Group("/api", logger, Recover(RecoverConfig{
+		OnPanic: func(c *Context, err *PanicError) {
+			reported = append(reported, err)
+		},
+	}))
+	api.Get("/panic", func(c *Context) error {
+		panic(errors.New("boom"))
+	})
+	api.Get("/abort", func(c *Context) error {
+		panic(http.ErrAbortHandler)
+	})
+
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/api/panic", nil))
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+	assert.Equal(t, w.Body.String(), http.StatusText(http.StatusInternalServerError)+"\n")
+	assert.Equal(t, len(reported), 1)
+	assert.Equal(t, reported[0].Value.(error).Error(), "boom")
+	assert.True(t, strings.Contains(string(reported[0].Stack), "TestRecover"))
+	var panicErr *PanicError
+	assert.True(t, errors.As(downstream, &panicErr))
+	assert.Equal(t, panicErr, reported[0])
+
+	assertPanics(t, func() {
+		s.Test().Request(httptest.NewRequest(http.MethodGet, "/api/abort", nil))
+	}, http.ErrAbortHandler)
+	assertPanics(t, func() {
+		s.Test().Request(httptest.NewRequest(http.MethodGet, "/unprotected", nil))
+	}, "unprotected")
+	assert.Equal(t, len(reported), 1)
+}
+
+func TestServerRecover(t *testing.T) {
+	var handled error
+	s := New(0, ServerOpts{
+		Recover: &RecoverConfig{
+			DisableStack: true,
+			OnPanic:      func(c *Context, err *PanicError) {},
+		},
+		ErrorHandler: func(c *Context, err error) {
+			handled = err
+			c.Status(http.StatusInternalServerError).JSON(JSON{"error": "internal error"})
+		},
+	})
+	s.Use(func(c *Context) error {
+		if c.Query("fail") != "" {
+			panic("middleware")
+		}
+		return nil
+	})
+	s.Get("/", func(c *Context) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	})
+	admin := s.Group("/admin", func(c *Context) error {
+		panic("auth")
+	})
+	admin.Get("/", func(c *Context) error {
+		return nil
+	})
+
+	for _, path := range []string{"/", "/?fail=1", "/admin"} {
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, w.Code, http.StatusInternalServerError, path)
+		assert.Equal(t, w.Body.String(), `{"error":"internal error"}`+"\n")
+		var panicErr *PanicError
+		assert.True(t, errors.As(handled, &panicErr))
+		assert.Empty(t, panicErr.Stack)
+	}
+}
+
+func assertPanics(t *testing.T, fn func(), expected any) {
+	t.Helper()
+	defer func() {
+		assert.Equal(t, recover(), expected)
+	}()
+	fn()
+}
@@ -10,6 +10,17 @@ import (
 type Request struct {
 	req     *http.Request
 	pattern string
+	next    func() error
+	// errorHandled is set once an error was replied, so the
+	// handlers running before do not reply to it again.
+	errorHandled bool
+	locals       map[any]any
+	decoding     *DecodeOptions
+	body         *cachedBody
+
+	multipart       *MultipartOptions
+	multipartParsed bool
+	multipartErr    error
 }
 
 func NewRequest(req *http.Request, pattern ...string) Request {
@@ -25,6 +36,28 @@ func (r *Request) PathRegistred() string {
 	return r.pattern
 }
 
+// Next runs the rest of the middleware chain and the handler, returning
+// their error so the calling middleware can act once they are done. The
+// error is already replied by then, so the status code and size of the
+// response are final.
+//
+//	server.Use(func(c *i9.Context) error {
+//		start := time.Now()
+//		err := c.Next()
+//		log.Printf("%s %s %v %v", c.Method(), c.Path(), time.Since(start), err)
+//		return err
+//	})
+//
+// A middleware returning without calling Next lets the chain go on,
+// unless it returned an error or sent a response. Next only runs the
+// chain once, later calls return nil.
+func (r *Request) Next() error {
+	if r.next == nil {
+		return nil
+	}
+	return r.next()
+}
+
 // HTTP returns the HTTP request.
 //
 //	func handler(req *nine.Request, res *nine.Response) error {
@@ -37,12 +70,38 @@ func (r *Request) HTTP() *http.Request {
 // Body returns the body of the HTTP request.
 //
 //	b := req.Body().Bytes()
+//
+// The body is read once and cached for the next calls, BodyParser and
+// Bind, which share its content: it must not be modified. The body is
+// empty when it could not be read, such as when it exceeds the BodyLimit
+// of the route.
 func (r *Request) Body() *bytes.Buffer {
-	b, _ := io.ReadAll(r.req.Body)
-	r.req.Body = io.NopCloser(bytes.NewReader(b))
+	b, _ := r.readBody()
 	return bytes.NewBuffer(b)
 }
 
+// readBody reads the body on the first call and returns the cached
+// content on the next ones. The body of the HTTP request is then
+// replaced by a reader of the content, to be read again.
+func (r *Request) readBody() ([]byte, error) {
+	if r.body == nil {
+		r.body = &cachedBody{}
+		if r.req.Body != nil {
+			r.body.b, r.body.err = io.ReadAll(r.req.Body)
+		}
+		if r.body.err != nil {
+			r.body.b = nil
+		}
+	}
+	r.req.Body = io.NopCloser(bytes.NewReader(r.body.b))
+	return r.body.b, r.body.err
+}
+
+type cachedBody struct {
+	b   []byte
+	err error
+}
+
 // Method returns the HTTP request method.
 //
 //	method := req.Method()
@@ -83,6 +142,29 @@ func (r *Request) Query(key string) string {
 	return r.req.URL.Query().Get(key)
 }
 
+// params returns the path parameters of the request, read from the values
+// set by the router or, when the request was not dispatched by a router,
+// by matching the path against the registered pattern.
+func (r *Request) params() map[string]string {
+	params := make(map[string]string)
+	if r.req.Pattern != "" {
+		for _, name := range patternParams(r.pattern) {
+			if value := r.req.PathValue(name); value != "" {
+				params[name] = value
+			}
+		}
+		return params
+	}
+	rt := newRouter()
+	rt.Handle(r.pattern, http.NotFoundHandler())
+	if route, values, ok := rt.lookup(r.Method(), requestHost(r.req), r.req.URL.EscapedPath()); ok {
+		for i, name := range route.params {
+			params[name] = values[i]
+		}
+	}
+	return params
+}
+
 // Context returns the context of the request,
 // which can be used to carry deadlines,
 // cancellation signals, and other request-scoped values.
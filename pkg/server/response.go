@@ -1,13 +1,18 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
+
+	"github.com/i9si-sistemas/nine/internal/xml"
 )
 
 type Response struct {
 	res        http.ResponseWriter
+	writer     *responseWriter
 	statusCode int
 	sent       bool
 }
@@ -15,15 +20,56 @@ type Response struct {
 const DefaultStatusCode = http.StatusOK
 
 func NewResponse(res http.ResponseWriter) Response {
+	writer := newResponseWriter(res)
 	return Response{
-		res:        res,
+		res:        writer,
+		writer:     writer,
 		statusCode: DefaultStatusCode,
 	}
 }
 
-// Sent returns true if the response has already been sent.
+// Sent returns true if the response has already been sent,
+// either through the Response or by writing to its http.ResponseWriter.
 func (r *Response) Sent() bool {
-	return r.sent
+	return r.sent || r.Written()
+}
+
+// Written reports whether the status and headers were written to the client.
+// Headers set afterwards are not sent.
+func (r *Response) Written() bool {
+	return r.writer.written()
+}
+
+// StatusCode returns the status code written to the client or, while
+// nothing was written, the one set with Status.
+//
+//	server.Use(func(c *i9.Context) error {
+//		err := c.Next()
+//		metrics.Observe(c.Path(), c.StatusCode(), c.Size())
+//		return err
+//	})
+func (r *Response) StatusCode() int {
+	if r.Written() {
+		return r.writer.status
+	}
+	return r.statusCode
+}
+
+// Size returns the number of body bytes written to the client.
+func (r *Response) Size() int64 {
+	if r.writer == nil {
+		return 0
+	}
+	return r.writer.size
+}
+
+// FirstByteTime returns the time the status and headers were written to
+// the client, the zero time while nothing was written.
+func (r *Response) FirstByteTime() time.Time {
+	if r.writer == nil {
+		return time.Time{}
+	}
+	return r.writer.firstByte
 }
 
 // HTTP returns the HTTP response.
@@ -82,6 +128,27 @@ func (r *Response) JSON(data any) error {
 	})
 }
 
+// XML sends an XML response by encoding the provided data
+// into XML format and setting the appropriate content-type and status code.
+// Maps such as nine.JSON are encoded as a <response> element
+// holding one element per key.
+func (r *Response) XML(data any) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	if err := xml.NewEncoder(&b).Encode(data); err != nil {
+		return err
+	}
+	return r.write(func() error {
+		r.res.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		if r.invalidStatusCode() {
+			r.statusCode = DefaultStatusCode
+		}
+		r.res.WriteHeader(r.statusCode)
+		_, err := r.res.Write(b.Bytes())
+		return err
+	})
+}
+
 // SendStatus sends the HTTP response with the specified status code.
 func (r *Response) SendStatus(statusCode int) error {
 	return r.write(func() error {
@@ -2,6 +2,9 @@ package server
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -24,3 +27,86 @@ func TestResponseWrite(t *testing.T) {
 	})
 	assert.NotEqual(t, err, errExecuted)
 }
+
+func TestResponseWriter(t *testing.T) {
+	var status int
+	var size int64
+	var written bool
+	s := New(0)
+	s.Use(func(c *Context) error {
+		err := c.Next()
+		status, size, written = c.StatusCode(), c.Size(), c.Written()
+		return err
+	})
+	raw := func(req *Request, res *Response) error {
+		w := res.HTTP()
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, "accepted")
+		assert.True(t, res.Sent())
+		assert.False(t, res.FirstByteTime().IsZero())
+		return nil
+	}
+	s.Get("/raw", raw)
+	s.Get("/sent", raw, func(req *Request, res *Response) error {
+		return res.Send([]byte("unreachable"))
+	})
+	s.Get("/stream", func(c *Context) error {
+		w := c.Response.HTTP()
+		w.Write([]byte("chunk"))
+		w.(http.Flusher).Flush()
+		return http.NewResponseController(w).Flush()
+	})
+	s.Get("/error", func(c *Context) error {
+		assert.Equal(t, c.Status(http.StatusTeapot).StatusCode(), http.StatusTeapot)
+		return errors.New("failed")
+	})
+
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/raw", nil))
+	assert.Equal(t, w.Code, http.StatusAccepted)
+	assert.Equal(t, w.Body.String(), "accepted")
+	assert.Equal(t, status, http.StatusAccepted)
+	assert.Equal(t, size, int64(len("accepted")))
+	assert.True(t, written)
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodGet, "/sent", nil))
+	assert.Equal(t, w.Body.String(), "accepted")
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodGet, "/stream", nil))
+	assert.True(t, w.Flushed)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, size, int64(len("chunk")))
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodGet, "/error", nil))
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+	assert.Equal(t, status, http.StatusInternalServerError)
+	assert.Equal(t, size, int64(w.Body.Len()))
+	assert.True(t, written)
+}
+
+func TestResponseWriterHijack(t *testing.T) {
+	s := New(0)
+	s.Get("/ws", func(c *Context) error {
+		conn, rw, err := c.Response.HTTP().(http.Hijacker).Hijack()
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		assert.True(t, c.Written())
+		assert.Equal(t, c.StatusCode(), http.StatusSwitchingProtocols)
+		rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		return rw.Flush()
+	})
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/ws")
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), "hijacked")
+
+	w := httptest.NewRecorder()
+	_, _, err = newResponseWriter(w).Hijack()
+	assert.True(t, errors.Is(err, http.ErrNotSupported))
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// responseWriter records what is written to the wrapped
+// http.ResponseWriter so it can be reported by the Response.
+//
+// It implements http.Flusher and http.Hijacker, and unwraps to the
+// original writer so http.ResponseController keeps working.
+type responseWriter struct {
+	http.ResponseWriter
+	status    int
+	size      int64
+	firstByte time.Time
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	if rw, ok := w.(*responseWriter); ok {
+		return rw
+	}
+	return &responseWriter{ResponseWriter: w}
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.written() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+	// informational responses are followed by the final status
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		return
+	}
+	w.status = code
+	w.firstByte = time.Now()
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.WriteHeader(http.StatusOK)
+	n, err := io.Copy(w.ResponseWriter, r)
+	w.size += n
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil && !w.written() {
+		w.status = http.StatusSwitchingProtocols
+		w.firstByte = time.Now()
+	}
+	return conn, rw, err
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) written() bool {
+	return w != nil && w.status != 0
+}
@@ -1,6 +1,9 @@
 package server
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/i9si-sistemas/stringx"
 )
 
@@ -15,6 +18,8 @@ func (s *Server) Group(basePath string, middlewares ...any) RouteManager {
 // and middleware stack
 type RouteGroup struct {
 	server      RouteManager
+	parent      *RouteGroup
+	host        string
 	basePath    string
 	middlewares []any
 }
@@ -37,18 +42,51 @@ func (s *Server) Route(basePath string, fn func(router RouteManager)) {
 }
 
 // Group creates a new route group with a base path and optional middlewares.
+// The nested group inherits the middlewares of the group.
 func (g *RouteGroup) Group(basePath string, middlewares ...any) RouteManager {
-	return NewRouteGroup(g.server, g.fullPath(basePath), append(g.middlewares, middlewares...)...)
+	return g.subgroup(basePath, middlewares...)
 }
 
+// Use adds middlewares to the group. They only apply to the routes
+// registered afterwards in the group and in its nested groups.
+// Route options such as BodyLimit are accepted too.
+//
+//	api := server.Group("/api")
+//	api.Get("/health", health) // public
+//	api.Use(auth)
+//	api.Get("/users", listUsers) // requires auth
 func (g *RouteGroup) Use(middlewares ...any) error {
-	return g.server.Use(middlewares...)
+	for _, middleware := range middlewares {
+		if _, ok := middleware.(RouteOption); ok {
+			continue
+		}
+		if _, err := validateHandler(middleware); err != nil {
+			return fmt.Errorf("invalid middleware: %w", err)
+		}
+	}
+	g.middlewares = slices.Concat(g.middlewares, middlewares)
+	return nil
 }
 
 // Route accepts a base path and a function to define routes within the group.
+// The routes defined within the function inherit the middlewares of the group.
 func (g *RouteGroup) Route(basePath string, fn func(router RouteManager)) {
-	group := g.server.Group(g.fullPath(basePath))
-	fn(group)
+	fn(g.subgroup(basePath))
+}
+
+func (g *RouteGroup) subgroup(basePath string, middlewares ...any) *RouteGroup {
+	group := NewRouteGroup(g.server, g.fullPath(basePath), middlewares...)
+	group.parent = g
+	group.host = g.host
+	return group
+}
+
+// chain returns the middlewares of the group preceded by the ones of its parents.
+func (g *RouteGroup) chain() []any {
+	if g.parent == nil {
+		return g.middlewares
+	}
+	return slices.Concat(g.parent.chain(), g.middlewares)
 }
 
 // Get registers a GET route within the group
@@ -57,6 +95,12 @@ func (g *RouteGroup) Get(path string, handlers ...any) error {
 	return g.server.Get(g.fullPath(path), handlers...)
 }
 
+// Head registers a HEAD route within the group
+func (g *RouteGroup) Head(path string, handlers ...any) error {
+	handlers = g.routeHandlers(handlers...)
+	return g.server.Head(g.fullPath(path), handlers...)
+}
+
 // Post registers a POST route within the group
 func (g *RouteGroup) Post(path string, handlers ...any) error {
 	handlers = g.routeHandlers(handlers...)
@@ -81,9 +125,30 @@ func (g *RouteGroup) Delete(path string, handlers ...any) error {
 	return g.server.Delete(g.fullPath(path), handlers...)
 }
 
+// Options registers an OPTIONS route within the group
+func (g *RouteGroup) Options(path string, handlers ...any) error {
+	handlers = g.routeHandlers(handlers...)
+	return g.server.Options(g.fullPath(path), handlers...)
+}
+
+// Any registers a route for every standard HTTP method within the group
+func (g *RouteGroup) Any(path string, handlers ...any) error {
+	handlers = g.routeHandlers(handlers...)
+	return g.server.Any(g.fullPath(path), handlers...)
+}
+
+// Match registers a route for each of the given methods within the group
+func (g *RouteGroup) Match(methods []string, path string, handlers ...any) error {
+	handlers = g.routeHandlers(handlers...)
+	return g.server.Match(methods, g.fullPath(path), handlers...)
+}
+
 // fullPath combines the group's base path with the provided path
 func (g *RouteGroup) fullPath(path string) string {
 	if path == "/" || path == "" {
+		if g.basePath == "" {
+			return "/"
+		}
 		return g.basePath
 	}
 	convert := func(s string) stringx.String {
@@ -94,6 +159,11 @@ func (g *RouteGroup) fullPath(path string) string {
 }
 
 // routeHandlers combines the group's middlewares with the provided handlers
+// and records the group's base path and host on the route
 func (g *RouteGroup) routeHandlers(handlers ...any) []any {
-	return append(g.middlewares, handlers...)
+	options := []any{groupPrefix(g.basePath)}
+	if g.host != "" {
+		options = append(options, routeHost(g.host))
+	}
+	return slices.Concat(g.chain(), handlers, options)
 }
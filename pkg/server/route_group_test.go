@@ -170,3 +170,48 @@ func TestMultipleRouteCall(t *testing.T) {
 	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
 	assert.Equal(t, w.Body.Bytes(), []byte("Home"))
 }
+
+func TestRouteGroupUse(t *testing.T) {
+	s := New(0)
+	var calls []string
+	middleware := func(name string) func(c *Context) error {
+		return func(c *Context) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	handler := func(c *Context) error {
+		return c.SendString(c.Path())
+	}
+	s.Get("/health", handler)
+	api := s.Group("/api", middleware("api"))
+	api.Get("/public", handler)
+	assert.NoError(t, api.Use(middleware("auth")))
+	assert.NotNil(t, api.Use("not a middleware"))
+	api.Get("/users", handler)
+	admin := api.Group("/admin", middleware("admin"))
+	admin.Get("/stats", handler)
+	api.Route("/posts", func(router RouteManager) {
+		router.Use(middleware("posts"))
+		router.Get("/", handler)
+	})
+	api.Get("/comments", handler)
+
+	tests := []struct {
+		path  string
+		calls []string
+	}{
+		{"/health", nil},
+		{"/api/public", []string{"api"}},
+		{"/api/users", []string{"api", "auth"}},
+		{"/api/admin/stats", []string{"api", "auth", "admin"}},
+		{"/api/posts", []string{"api", "auth", "posts"}},
+		{"/api/comments", []string{"api", "auth"}},
+	}
+	for _, tt := range tests {
+		calls = nil
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, w.Code, http.StatusOK, tt.path)
+		assert.Equal(t, calls, tt.calls, tt.path)
+	}
+}
@@ -0,0 +1,432 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// router is the default HTTPRequestMultiplexer used by the Server.
+//
+// Routes are compiled into a prefix tree where every node represents one
+// path segment. Matching walks the request path once, preferring static
+// segments over parameters and parameters over catch-alls, and backtracks
+// when a more specific branch does not lead to a registered route.
+type router struct {
+	root             *node
+	hosts            []*hostTree
+	notFound         http.Handler
+	methodNotAllowed http.Handler
+	pathPolicy       PathPolicy
+	caseInsensitive  bool
+}
+
+type node struct {
+	static     map[string]*node
+	params     []*node
+	wildcard   *node
+	expr       string
+	constraint constraint
+	handlers   map[string]route
+}
+
+// route is a handler registered on a node. Param names belong to the
+// route rather than to the nodes so routes sharing a branch can name
+// their parameters differently.
+type route struct {
+	method  string
+	pattern string
+	params  []string
+	handler http.Handler
+}
+
+func newRouter() *router {
+	return &router{
+		root: new(node),
+		notFound: &Error{
+			StatusCode: http.StatusNotFound,
+			Err:        errors.New(http.StatusText(http.StatusNotFound)),
+		},
+		methodNotAllowed: &Error{
+			StatusCode: http.StatusMethodNotAllowed,
+			Err:        errors.New(http.StatusText(http.StatusMethodNotAllowed)),
+		},
+	}
+}
+
+// Handle registers the handler for the given pattern.
+//
+// The pattern follows the http.ServeMux syntax: an optional method and
+// host followed by a path where `{name}` matches a single segment and a trailing
+// `{name...}` matches the remainder of the path. A parameter may also be
+// constrained with `{name<constraint>}`, see constraints, and a trailing
+// `{name?}` parameter makes the last segment optional.
+// Host labels may be params as well, see hostTree.
+//
+// Like http.ServeMux, Handle panics when a handler is already registered
+// for the same method and path, whatever the names of its params.
+func (rt *router) Handle(pattern string, handler http.Handler) {
+	method, rest := splitPattern(pattern)
+	host, path := splitHost(rest)
+	root := rt.root
+	if host != "" {
+		root = rt.hostTree(host).root
+	}
+	segments := pathSegments(path)
+	if name, expr, kind := parseSegment(segments[len(segments)-1]); kind == segmentOptional {
+		parent := segments[:len(segments)-1]
+		if len(parent) == 0 {
+			parent = []string{""}
+		}
+		rt.insert(root, method, pattern, parent, handler)
+		segments[len(segments)-1] = "{" + name + paramConstraint(expr) + "}"
+	}
+	rt.insert(root, method, pattern, segments, handler)
+}
+
+func (rt *router) insert(root *node, method, pattern string, segments []string, handler http.Handler) {
+	n := root
+	_, rest := splitPattern(pattern)
+	host, _ := splitHost(rest)
+	params := hostParams(host)
+	for _, segment := range segments {
+		name, _, kind := parseSegment(segment)
+		if kind != segmentStatic {
+			params = append(params, name)
+		} else if rt.caseInsensitive {
+			segment = strings.ToLower(segment)
+		}
+		n = n.child(segment)
+	}
+	if n.handlers == nil {
+		n.handlers = make(map[string]route)
+	}
+	if existing, ok := n.handlers[method]; ok {
+		panic(fmt.Sprintf("pattern %q conflicts with pattern %q", pattern, existing.pattern))
+	}
+	n.handlers[method] = route{
+		method:  method,
+		pattern: pattern,
+		params:  params,
+		handler: handler,
+	}
+}
+
+// ServeHTTP dispatches the request to the handler whose pattern matches
+// the request method and path. When the path exists but the method does
+// not, it replies 405 with the Allow header listing the registered methods.
+// Paths that only match in their canonical form are handled according
+// to the router's PathPolicy.
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	host := requestHost(r)
+	route, values, ok := rt.lookup(r.Method, host, path)
+	if rt.pathPolicy != PathStrict {
+		if canonical, found := rt.canonical(r.Method, host, path, ok); found {
+			if rt.pathPolicy == PathRedirect {
+				redirectToCanonical(w, r, canonical)
+				return
+			}
+			setPath(r.URL, canonical)
+			path = canonical
+			route, values, ok = rt.lookup(r.Method, host, path)
+		}
+	}
+	if !ok {
+		if allow := rt.allowed(host, path); len(allow) > 0 {
+			w.Header().Set("Allow", strings.Join(allow, ", "))
+			rt.methodNotAllowed.ServeHTTP(w, r)
+			return
+		}
+		rt.notFound.ServeHTTP(w, r)
+		return
+	}
+	for i, name := range route.params {
+		r.SetPathValue(name, values[i])
+	}
+	r.Pattern = route.pattern
+	if r.Method == http.MethodHead && route.method == http.MethodGet {
+		w = &headResponseWriter{w}
+	}
+	route.handler.ServeHTTP(w, r)
+}
+
+// headResponseWriter discards the body written by a GET handler
+// answering a HEAD request.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// lookup finds the route registered for method, host and path along
+// with the values of its host and path parameters. Routes bound to a
+// host take precedence over the routes available on every host.
+func (rt *router) lookup(method, host, path string) (route, []string, bool) {
+	accept := func(n *node) bool {
+		_, ok := n.handler(method)
+		return ok
+	}
+	for _, ht := range rt.hosts {
+		values, ok := ht.match(host)
+		if !ok {
+			continue
+		}
+		if n := rt.find(ht.root, strings.TrimPrefix(path, "/"), &values, accept); n != nil {
+			r, _ := n.handler(method)
+			return r, values, true
+		}
+	}
+	var values []string
+	n := rt.find(rt.root, strings.TrimPrefix(path, "/"), &values, accept)
+	if n == nil {
+		return route{}, nil, false
+	}
+	r, _ := n.handler(method)
+	return r, values, true
+}
+
+// allowed returns the sorted methods registered for every route matching host and path.
+func (rt *router) allowed(host, path string) []string {
+	var methods []string
+	collect := func(n *node) bool {
+		for method := range n.handlers {
+			methods = append(methods, method)
+			if method == http.MethodGet {
+				methods = append(methods, http.MethodHead)
+			}
+		}
+		return false
+	}
+	for _, ht := range rt.hosts {
+		if values, ok := ht.match(host); ok {
+			rt.find(ht.root, strings.TrimPrefix(path, "/"), &values, collect)
+		}
+	}
+	var values []string
+	rt.find(rt.root, strings.TrimPrefix(path, "/"), &values, collect)
+	slices.Sort(methods)
+	return slices.Compact(methods)
+}
+
+func (n *node) handler(method string) (route, bool) {
+	if r, ok := n.handlers[method]; ok {
+		return r, true
+	}
+	if method == http.MethodHead {
+		if r, ok := n.handlers[http.MethodGet]; ok {
+			return r, true
+		}
+	}
+	r, ok := n.handlers[""]
+	return r, ok
+}
+
+func (n *node) child(segment string) *node {
+	_, expr, kind := parseSegment(segment)
+	switch kind {
+	case segmentWildcard:
+		if n.wildcard == nil {
+			n.wildcard = new(node)
+		}
+		return n.wildcard
+	case segmentParam, segmentOptional:
+		for _, c := range n.params {
+			if c.expr == expr {
+				return c
+			}
+		}
+		c := &node{expr: expr}
+		if expr == "" {
+			n.params = append(n.params, c)
+			return c
+		}
+		// constrained params are tried before unconstrained ones
+		c.constraint = mustCompileConstraint(expr)
+		i := 0
+		for i < len(n.params) && n.params[i].constraint != nil {
+			i++
+		}
+		n.params = slices.Insert(n.params, i, c)
+		return c
+	}
+	if n.static == nil {
+		n.static = make(map[string]*node)
+	}
+	c, ok := n.static[segment]
+	if !ok {
+		c = new(node)
+		n.static[segment] = c
+	}
+	return c
+}
+
+// find walks the tree from n looking for the first node matching the
+// escaped path that is accepted by the accept function. Segments are
+// unescaped one by one so encoded slashes stay inside their segment.
+func (rt *router) find(n *node, path string, values *[]string, accept func(*node) bool) *node {
+	segment, rest, more := strings.Cut(path, "/")
+	segment = unescapeSegment(segment)
+	next := func(c *node) *node {
+		if !more {
+			if accept(c) {
+				return c
+			}
+			return nil
+		}
+		return rt.find(c, rest, values, accept)
+	}
+	key := segment
+	if rt.caseInsensitive {
+		key = strings.ToLower(segment)
+	}
+	if c, ok := n.static[key]; ok {
+		if found := next(c); found != nil {
+			return found
+		}
+	}
+	if segment != "" {
+		for _, c := range n.params {
+			if c.constraint != nil && !c.constraint(segment) {
+				continue
+			}
+			size := len(*values)
+			*values = append(*values, segment)
+			if found := next(c); found != nil {
+				return found
+			}
+			*values = (*values)[:size]
+		}
+	}
+	if c := n.wildcard; c != nil && accept(c) {
+		*values = append(*values, unescapeSegment(path))
+		return c
+	}
+	return nil
+}
+
+func unescapeSegment(segment string) string {
+	if strings.IndexByte(segment, '%') < 0 {
+		return segment
+	}
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		return unescaped
+	}
+	return segment
+}
+
+type segmentKind int
+
+const (
+	segmentStatic segmentKind = iota
+	segmentParam
+	segmentOptional
+	segmentWildcard
+)
+
+// parseSegment parses a pattern segment such as `users`, `{id}`,
+// `{id<int>}`, `{id?}` or `{path...}`.
+func parseSegment(segment string) (name, expr string, kind segmentKind) {
+	if len(segment) < 2 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return segment, "", segmentStatic
+	}
+	name = segment[1 : len(segment)-1]
+	if wildcard, ok := strings.CutSuffix(name, "..."); ok {
+		return wildcard, "", segmentWildcard
+	}
+	kind = segmentParam
+	if optional, ok := strings.CutSuffix(name, "?"); ok {
+		name, kind = optional, segmentOptional
+	}
+	if i := strings.IndexByte(name, '<'); i > 0 && strings.HasSuffix(name, ">") {
+		return name[:i], name[i+1 : len(name)-1], kind
+	}
+	return name, "", kind
+}
+
+func paramConstraint(expr string) string {
+	if expr == "" {
+		return ""
+	}
+	return "<" + expr + ">"
+}
+
+// splitHost separates the host from the path of a pattern without method.
+func splitHost(pattern string) (host, path string) {
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		return pattern[:i], pattern[i:]
+	}
+	return "", pattern
+}
+
+func splitPattern(pattern string) (method, path string) {
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		return pattern[:i], strings.TrimLeft(pattern[i+1:], " \t")
+	}
+	return "", pattern
+}
+
+// pathSegments splits a pattern path on the slashes
+// that are not part of a parameter constraint.
+func pathSegments(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	var (
+		segments []string
+		depth    int
+		start    int
+	)
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '/':
+			if depth <= 0 {
+				segments = append(segments, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, path[start:])
+}
+
+var (
+	ErrInvalidCatchAll = errors.New("catch-all param must be the last segment")
+	ErrInvalidOptional = errors.New("optional param must be the last segment")
+)
+
+// validatePattern reports whether the params of the pattern are
+// well placed and every constraint compiles.
+func validatePattern(pattern string) error {
+	host, path := splitHost(pattern)
+	for _, label := range hostLabels(host) {
+		if _, expr, _ := parseSegment(label); expr != "" {
+			if _, err := compileConstraint(expr); err != nil {
+				return err
+			}
+		}
+	}
+	segments := pathSegments(path)
+	for i, segment := range segments {
+		name, expr, kind := parseSegment(segment)
+		last := i == len(segments)-1
+		switch {
+		case kind == segmentWildcard && !last:
+			return fmt.Errorf("%w: %s", ErrInvalidCatchAll, name)
+		case kind == segmentOptional && !last:
+			return fmt.Errorf("%w: %s", ErrInvalidOptional, name)
+		}
+		if expr != "" {
+			if _, err := compileConstraint(expr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestRouter(t *testing.T) {
+	rt := newRouter()
+	handle := func(pattern string) {
+		rt.Handle(pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.Pattern, " ", r.PathValue("id"), r.PathValue("name"), r.PathValue("path"))
+		}))
+	}
+	handle("GET /")
+	handle("GET /users")
+	handle("GET /users/new")
+	handle("GET /users/{id}")
+	handle("GET /users/{id}/posts/{name}")
+	handle("POST /users/{name}/follow")
+	handle("GET /files/{path...}")
+
+	tests := []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{http.MethodGet, "/", http.StatusOK, "GET / "},
+		{http.MethodGet, "/users", http.StatusOK, "GET /users "},
+		{http.MethodGet, "/users/new", http.StatusOK, "GET /users/new "},
+		{http.MethodGet, "/users/42", http.StatusOK, "GET /users/{id} 42"},
+		{http.MethodGet, "/users/42/posts/go", http.StatusOK, "GET /users/{id}/posts/{name} 42go"},
+		{http.MethodGet, "/users/new/posts/go", http.StatusOK, "GET /users/{id}/posts/{name} newgo"},
+		{http.MethodPost, "/users/gopher/follow", http.StatusOK, "POST /users/{name}/follow gopher"},
+		{http.MethodGet, "/files/a/b.txt", http.StatusOK, "GET /files/{path...} a/b.txt"},
+		{http.MethodHead, "/users", http.StatusOK, ""},
+		{http.MethodGet, "/users/", http.StatusNotFound, ""},
+		{http.MethodGet, "/files", http.StatusNotFound, ""},
+		{http.MethodGet, "/unknown", http.StatusNotFound, ""},
+		{http.MethodGet, "/users/42/posts", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, w.Code, tt.code, tt.method, tt.path)
+		if tt.code == http.StatusOK {
+			assert.Equal(t, w.Body.String(), tt.body)
+		}
+	}
+}
+
+func TestRouterConflicts(t *testing.T) {
+	rt := newRouter()
+	rt.Handle("GET /users/{id}", http.NotFoundHandler())
+	rt.Handle("POST /users/{id}", http.NotFoundHandler())
+	rt.Handle("/users/{id}", http.NotFoundHandler())
+	assertPanics(t, func() {
+		rt.Handle("GET /users/{name}", http.NotFoundHandler())
+	}, `pattern "GET /users/{name}" conflicts with pattern "GET /users/{id}"`)
+
+	s := New(0)
+	s.Get("/a", func(c *Context) error { return c.SendString("first") })
+	s.Get("/a", func(c *Context) error { return c.SendString("second") })
+	assertPanics(t, func() {
+		s.Test().Request(httptest.NewRequest(http.MethodGet, "/a", nil))
+	}, `pattern "GET /a" conflicts with pattern "GET /a"`)
+}
+
+func TestRouterConstraints(t *testing.T) {
+	s := New(0)
+	handler := func(name string) func(c *Context) error {
+		return func(c *Context) error {
+			var params struct {
+				ID   int    `param:"id"`
+				Slug string `param:"slug"`
+			}
+			if err := c.ParamsParser(&params); err != nil {
+				return err
+			}
+			return c.SendString(fmt.Sprint(name, " ", params.ID, params.Slug, c.Param("uuid")))
+		}
+	}
+	assert.NoError(t, s.Get("/users/:id<int>", handler("int")))
+	assert.NoError(t, s.Get("/users/:slug", handler("slug")))
+	assert.NoError(t, s.Get("/posts/{slug<[a-z0-9-]+>}", handler("regex")))
+	assert.NoError(t, s.Get("/tokens/:uuid<uuid>", handler("uuid")))
+	assert.NotNil(t, s.Get("/broken/:id<[a-z>", handler("broken")))
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/users/42", http.StatusOK, "int 42"},
+		{"/users/-7", http.StatusOK, "int -7"},
+		{"/users/gopher", http.StatusOK, "slug 0gopher"},
+		{"/posts/hello-world-2", http.StatusOK, "regex 0hello-world-2"},
+		{"/posts/Hello", http.StatusNotFound, ""},
+		{"/tokens/3f2504e0-4f89-11d3-9a0c-0305e82c3301", http.StatusOK, "uuid 03f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		{"/tokens/42", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, w.Code, tt.code, tt.path)
+		if tt.code == http.StatusOK {
+			assert.Equal(t, w.Body.String(), tt.body)
+		}
+	}
+}
+
+func TestRouterCatchAllAndOptional(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>index</h1>"), 0644))
+
+	s := New(0)
+	s.ServeFiles("/", dir)
+	s.Route("/api", func(router RouteManager) {
+		router.Get("/files/*path", func(c *Context) error {
+			var params struct {
+				Path string `param:"path"`
+			}
+			if err := c.ParamsParser(&params); err != nil {
+				return err
+			}
+			return c.SendString("file:" + params.Path)
+		})
+		router.Get("/posts/:id<int>?", func(c *Context) error {
+			var params struct {
+				ID int `param:"id"`
+			}
+			if err := c.ParamsParser(&params); err != nil {
+				return err
+			}
+			return c.SendString(fmt.Sprint("post:", params.ID, c.Param("id")))
+		}, Name("posts"))
+	})
+	assert.True(t, errors.Is(s.Get("/a/*rest/b", func(c *Context) error { return nil }), ErrInvalidCatchAll))
+	assert.True(t, errors.Is(s.Get("/a/:id?/b", func(c *Context) error { return nil }), ErrInvalidOptional))
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/api/files/docs/readme.md", http.StatusOK, "file:docs/readme.md"},
+		{"/api/files/", http.StatusOK, "file:"},
+		{"/api/posts/42", http.StatusOK, "post:4242"},
+		{"/api/posts", http.StatusOK, "post:0"},
+		{"/api/posts/abc", http.StatusNotFound, ""},
+		{"/", http.StatusOK, "<h1>index</h1>"},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, w.Code, tt.code, tt.path)
+		if tt.code == http.StatusOK {
+			assert.Equal(t, w.Body.String(), tt.body)
+		}
+	}
+
+	url, err := s.URL("posts")
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/api/posts")
+	url, err = s.URL("posts", "id", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/api/posts/3")
+}
+
+func TestServerNotFound(t *testing.T) {
+	s := New(0)
+	s.Get("/", func(req *Request, res *Response) error {
+		return res.Send([]byte("home"))
+	})
+	s.Get("/user/:id", func(req *Request, res *Response) error {
+		return res.Send([]byte(req.Param("id")))
+	})
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/user/7", nil))
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), "7")
+	for _, path := range []string{"/about", "/user", "/user/7/posts"} {
+		w = s.Test().Request(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, w.Code, http.StatusNotFound, path)
+	}
+}
+
+func TestServerMethodNotAllowed(t *testing.T) {
+	s := New(0)
+	handler := func(req *Request, res *Response) error {
+		return res.Send([]byte(req.Method()))
+	}
+	s.Get("/users/:id", handler)
+	s.Put("/users/:id", handler)
+	s.Post("/users/new", handler)
+
+	w := s.Test().Request(httptest.NewRequest(http.MethodDelete, "/users/42", nil))
+	assert.Equal(t, w.Code, http.StatusMethodNotAllowed)
+	assert.Equal(t, w.Header().Get("Allow"), "GET, HEAD, PUT")
+	assert.Equal(t, w.Body.String(), http.StatusText(http.StatusMethodNotAllowed)+"\n")
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodDelete, "/users/new", nil))
+	assert.Equal(t, w.Code, http.StatusMethodNotAllowed)
+	assert.Equal(t, w.Header().Get("Allow"), "GET, HEAD, POST, PUT")
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodPut, "/users/new", nil))
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), http.MethodPut)
+
+	w = s.Test().Request(httptest.NewRequest(http.MethodDelete, "/posts", nil))
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Empty(t, w.Header().Get("Allow"))
+}
+
+func benchmarkRoutes() []string {
+	var routes []string
+	for _, resource := range []string{"users", "posts", "comments", "orders", "products"} {
+		routes = append(routes,
+			"/"+resource,
+			"/"+resource+"/{id}",
+			"/"+resource+"/{id}/items",
+			"/"+resource+"/{id}/items/{item}",
+			"/api/v1/"+resource+"/search",
+		)
+	}
+	return routes
+}
+
+func BenchmarkRouter(b *testing.B) {
+	rt := newRouter()
+	noop := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	for _, r := range benchmarkRoutes() {
+		rt.Handle(http.MethodGet+" "+r, noop)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/products/42/items/7", nil)
+	w := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		rt.ServeHTTP(w, req)
+	}
+}
+
+// BenchmarkServeMuxPatternExists reproduces the previous matching path:
+// http.ServeMux dispatch followed by a regex binary search over the
+// sorted route patterns to detect not found requests.
+func BenchmarkServeMuxPatternExists(b *testing.B) {
+	mux := http.NewServeMux()
+	var routes Routes
+	for _, r := range benchmarkRoutes() {
+		pattern := http.MethodGet + " " + r
+		routes = append(routes, Router{pattern: pattern})
+		mux.Handle(pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			legacyPatternExists(routes, r.Method+" "+r.URL.Path)
+		}))
+	}
+	req := httptest.NewRequest(http.MethodGet, "/products/42/items/7", nil)
+	w := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		mux.ServeHTTP(w, req)
+	}
+}
+
+func legacyPatternExists(routes Routes, pattern string) bool {
+	sort.Sort(routes)
+	lower, high := 0, len(routes)-1
+	for lower <= high {
+		middle := math.Floor(float64(lower) + float64(high-lower)/2)
+		route := routes[int(middle)]
+		regex := "^" + regexp.MustCompile(`\{[a-zA-Z0-9_]+\}`).ReplaceAllString(route.pattern, `([^/]+)`) + "$"
+		if matched, _ := regexp.MatchString(regex, pattern); matched {
+			return true
+		}
+		if route.pattern < pattern {
+			lower = int(middle) + 1
+		} else {
+			high = int(middle) - 1
+		}
+	}
+	return false
+}
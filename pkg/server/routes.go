@@ -1,5 +1,13 @@
 package server
 
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
 type Routes []Router
 
 func (r Routes) Len() int {
@@ -12,4 +20,106 @@ func (r Routes) Less(i, j int) bool {
 
 func (r Routes) Swap(i, j int) {
 	r[i], r[j] = r[j], r[i]
-}
\ No newline at end of file
+}
+
+// RouteOption configures a route at registration time.
+// Options can be passed anywhere among the handlers given to
+// Get, Post, Put, Patch, Delete, Head, Options, Any and Match.
+//
+//	server.Get("/users/:id", showUser, i9.Name("users.show"))
+type RouteOption func(r *Router)
+
+// Name assigns a unique name to the route so its URL can be
+// rebuilt with Server.URL or Context.URLFor.
+func Name(name string) RouteOption {
+	return func(r *Router) {
+		r.name = name
+	}
+}
+
+// routeOptions separates the route options from the handlers.
+func routeOptions(handlers []any) (options []RouteOption, rest []any) {
+	for _, h := range handlers {
+		if option, ok := h.(RouteOption); ok {
+			options = append(options, option)
+			continue
+		}
+		rest = append(rest, h)
+	}
+	return
+}
+
+// RouteInfo describes a route registered on the Server.
+type RouteInfo struct {
+	// Name is the name given with the Name option, if any.
+	Name string
+	// Method is the HTTP method matched by the route,
+	// empty when the route matches every method, see Server.Mount.
+	Method string
+	// Host is the host pattern the route is bound to, if any.
+	Host string
+	// Path is the path pattern using the `{name}` parameter syntax.
+	Path string
+	// Prefix is the base path of the group the route was registered in.
+	Prefix string
+	// Middlewares is the number of global and route middlewares
+	// that run before the handler.
+	Middlewares int
+	// Handler is the name of the function handling the route.
+	Handler string
+}
+
+// Routes returns every route registered on the server,
+// including static file mounts, in registration order.
+func (s *Server) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(s.routes))
+	for _, r := range s.routes {
+		method, rest := splitPattern(r.pattern)
+		host, path := splitHost(rest)
+		routes = append(routes, RouteInfo{
+			Name:        r.name,
+			Method:      method,
+			Host:        host,
+			Path:        path,
+			Prefix:      r.prefix,
+			Middlewares: len(s.globalMiddlewares) + len(r.middlewares),
+			Handler:     r.handlerName,
+		})
+	}
+	return routes
+}
+
+// routeTable renders the registered routes as an aligned text table.
+func (s *Server) routeTable() string {
+	b := new(strings.Builder)
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tNAME\tMIDDLEWARES\tHANDLER")
+	for _, r := range s.Routes() {
+		method := r.Method
+		if method == "" {
+			method = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", method, r.Host+r.Path, r.Name, r.Middlewares, r.Handler)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// groupPrefix records the base path of the group registering the route.
+func groupPrefix(prefix string) RouteOption {
+	return func(r *Router) {
+		r.prefix = prefix
+	}
+}
+
+// handlerName returns the name of the function behind the handler.
+func handlerName(h any) string {
+	v := reflect.ValueOf(h)
+	if v.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", h)
+	}
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		return f.Name()
+	}
+	return v.Type().String()
+}
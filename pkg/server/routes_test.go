@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/i9si-sistemas/assert"
@@ -43,3 +44,54 @@ func TestRoutes(t *testing.T) {
 	w = server.Test().Request(req)
 	assert.Equal(t, w.Code, 404, "status should be 404")
 }
+
+func listUsers(c *Context) error {
+	return c.SendString("users")
+}
+
+func TestServerRoutes(t *testing.T) {
+	server := New(0)
+	logger := func(c *Context) error { return nil }
+	server.Use(logger)
+	server.Get("/health", func(req *Request, res *Response) error {
+		return res.SendStatus(http.StatusOK)
+	})
+	api := server.Group("/api", logger)
+	api.Get("/users", listUsers, Name("users.list"))
+	api.Group("/admin").Match([]string{http.MethodPut, http.MethodPatch}, "/users/:id", logger, listUsers)
+	server.ServeFiles("/static", t.TempDir())
+
+	routes := server.Routes()
+	assert.Equal(t, len(routes), 5)
+
+	assert.Equal(t, routes[0].Method, http.MethodGet)
+	assert.Equal(t, routes[0].Path, "/health")
+	assert.Equal(t, routes[0].Prefix, "")
+	assert.Equal(t, routes[0].Middlewares, 1)
+
+	assert.Equal(t, routes[1], RouteInfo{
+		Name:        "users.list",
+		Method:      http.MethodGet,
+		Path:        "/api/users",
+		Prefix:      "/api",
+		Middlewares: 2,
+		Handler:     "github.com/i9si-sistemas/nine/pkg/server.listUsers",
+	})
+
+	for i, method := range []string{http.MethodPut, http.MethodPatch} {
+		route := routes[2+i]
+		assert.Equal(t, route.Method, method)
+		assert.Equal(t, route.Path, "/api/admin/users/{id}")
+		assert.Equal(t, route.Prefix, "/api/admin")
+		assert.Equal(t, route.Middlewares, 3)
+	}
+
+	assert.Equal(t, routes[4].Method, http.MethodGet)
+	assert.Equal(t, routes[4].Path, "/static/{filepath...}")
+	assert.True(t, strings.HasPrefix(routes[4].Handler, "ServeFiles("))
+
+	table := server.routeTable()
+	assert.True(t, strings.HasPrefix(table, "METHOD"))
+	assert.True(t, strings.Contains(table, "/api/users"))
+	assert.True(t, strings.Contains(table, "users.list"))
+}
@@ -8,13 +8,13 @@ import (
 	"io"
 	"io/fs"
 	"log"
-	"math"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"regexp"
-	"sort"
+	"slices"
 
 	"github.com/i9si-sistemas/stringx"
 )
@@ -28,23 +28,72 @@ type Server struct {
 	mux               HTTPRequestMultiplexer
 	httpServer        *http.Server
 	routes            Routes
+	notFound          Routes
+	methodNotAllowed  Routes
+	names             map[string]string
 	globalMiddlewares []Handler
 	addr, port        string
 	corsEnabled       bool
 	corsHandler       HandlerWithContext
+	errorHandler      func(c *Context, err error)
+	recover           Handler
 	listenFn          func() error
+	printRoutes       bool
+	pathPolicy        PathPolicy
+	caseInsensitive   bool
+	decoding          DecodeOptions
+	bodyLimit         int64
+	multipart         MultipartOptions
 }
 
 type Router struct {
+	name         string
+	host         string
+	prefix       string
 	pattern      string
 	handler      Handler
+	handlerName  string
 	middlewares  []Handler
 	servingFiles bool
+	decoding     *DecodeOptions
+	bodyLimit    int64
+	multipart    *MultipartOptions
 }
 
 type ServerOpts struct {
+	// Mux replaces the built-in router. Route matching, parameter
+	// extraction and not found detection are delegated to it, so
+	// patterns must use a syntax it understands: param constraints
+	// such as `:id<int>` are only supported by the built-in router.
 	Mux      HTTPRequestMultiplexer
 	ListenFn func() error
+	// PrintRoutes logs the route table next to the startup banner.
+	PrintRoutes bool
+	// PathPolicy defines how paths such as `/users/` or `/users//1`
+	// are handled when only their canonical form matches a route.
+	// Defaults to PathStrict.
+	PathPolicy PathPolicy
+	// CaseInsensitive matches the static segments of the routes
+	// regardless of case. Param values keep the case of the request.
+	CaseInsensitive bool
+	// ErrorHandler replies to the requests whose handler or middleware
+	// returned an error. Defaults to DefaultErrorHandler.
+	ErrorHandler func(c *Context, err error)
+	// Recover recovers from the panics raised by every handler and
+	// middleware when set, see Recover. It runs before the middlewares
+	// of the routes and groups and the global ones.
+	Recover *RecoverConfig
+	// Decoding configures how BodyParser and Bind decode request
+	// bodies, unless a route sets its own with the Decoding option.
+	Decoding DecodeOptions
+	// BodyLimit limits the size of the request bodies to a number of
+	// bytes, unless a route or group sets its own with the BodyLimit
+	// option. Larger bodies are replied with 413 Request Entity Too
+	// Large. Zero means no limit.
+	BodyLimit int64
+	// Multipart configures how multipart forms are read, unless a route
+	// or group sets its own with the Multipart option.
+	Multipart MultipartOptions
 }
 
 // New creates a new `Server` instance bound to the specified port.
@@ -54,8 +103,8 @@ func New[P int | string](
 	opts ...ServerOpts,
 ) (s *Server) {
 	s = &Server{
-		mux:        http.NewServeMux(),
 		routes:     make([]Router, 0),
+		names:      make(map[string]string),
 		port:       fmt.Sprint(port),
 		httpServer: new(http.Server),
 	}
@@ -63,10 +112,31 @@ func New[P int | string](
 		customOptions := opts[0]
 		s.mux = customOptions.Mux
 		s.listenFn = customOptions.ListenFn
+		s.printRoutes = customOptions.PrintRoutes
+		s.pathPolicy = customOptions.PathPolicy
+		s.caseInsensitive = customOptions.CaseInsensitive
+		s.errorHandler = customOptions.ErrorHandler
+		if customOptions.Recover != nil {
+			s.recover, _ = validateHandler(Recover(*customOptions.Recover))
+		}
+		s.decoding = customOptions.Decoding
+		s.bodyLimit = customOptions.BodyLimit
+		s.multipart = customOptions.Multipart
+	}
+	if s.mux == nil {
+		s.mux = s.newRouter()
 	}
 	return
 }
 
+// newRouter creates the built-in router configured with the server options.
+func (s *Server) newRouter() *router {
+	rt := newRouter()
+	rt.pathPolicy = s.pathPolicy
+	rt.caseInsensitive = s.caseInsensitive
+	return rt
+}
+
 func (s *Server) EnableCors(h HandlerWithContext) {
 	s.corsEnabled = true
 	s.corsHandler = h
@@ -86,8 +156,9 @@ func (s *Server) EnableCors(h HandlerWithContext) {
 //	server.ServeFiles("/", "./static")
 func (s *Server) ServeFiles(pattern, path string) {
 	r := Router{
-		pattern:      s.routePattern(http.MethodGet, pattern),
+		pattern:      s.routePattern(http.MethodGet, filesPattern(pattern)),
 		handler:      ServeFiles(http.Dir(path)),
+		handlerName:  fmt.Sprintf("ServeFiles(%q)", path),
 		servingFiles: true,
 	}
 	s.registerRoute(r)
@@ -112,52 +183,17 @@ func (s *Server) ServeFiles(pattern, path string) {
 //	server.ServeFilesWithFS("/", staticFiles)
 func (s *Server) ServeFilesWithFS(pattern string, fs fs.FS) {
 	r := Router{
-		pattern:      s.routePattern(http.MethodGet, pattern),
+		pattern:      s.routePattern(http.MethodGet, filesPattern(pattern)),
 		handler:      ServeFiles(http.FS(fs)),
+		handlerName:  fmt.Sprintf("ServeFilesWithFS(%T)", fs),
 		servingFiles: true,
 	}
 	s.registerRoute(r)
 }
 
-func (s *Server) notFoundMiddleware(req *Request, res *Response) error {
-	if exists := s.patternExists(req.Method(), req.Path()); !exists {
-		code := http.StatusNotFound
-		return &Error{
-			StatusCode: code,
-			Err:        errors.New(http.StatusText(code)),
-		}
-	}
-	return nil
-}
-
-func (s *Server) patternExists(method, pattern string) bool {
-	sort.Sort(s.routes)
-	pattern = s.routePattern(method, pattern)
-	lower, high := 0, len(s.routes)-1
-	for lower <= high {
-		middle := math.Floor(float64(lower) + float64(high-lower)/2)
-		route := s.routes[int(middle)]
-		regex := patternToRegex(route.pattern)
-		matched, err := regexp.MatchString(regex, pattern)
-		if err != nil {
-			return false
-		}
-		if matched {
-			return true
-		}
-		if route.pattern < pattern {
-			lower = int(middle) + 1
-		} else {
-			high = int(middle) - 1
-		}
-
-	}
-	return false
-}
-
-func patternToRegex(pattern string) string {
-	regexPattern := regexp.MustCompile(`\{[a-zA-Z0-9_]+\}`).ReplaceAllString(pattern, `([^/]+)`)
-	return "^" + regexPattern + "$"
+// filesPattern returns a pattern matching every path under the given prefix.
+func filesPattern(pattern string) string {
+	return path.Join(pattern, "{filepath...}")
 }
 
 func (s *Server) Port() string {
@@ -179,7 +215,16 @@ type ServerHandler struct {
 }
 
 func (s *ServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	s.mux.ServeHTTP(w, r)
+	ctx := context.WithValue(r.Context(), serverContextKey{}, s.Server)
+	s.mux.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type serverContextKey struct{}
+
+// serverFromContext returns the Server handling the request, if any.
+func serverFromContext(ctx context.Context) (*Server, bool) {
+	s, ok := ctx.Value(serverContextKey{}).(*Server)
+	return s, ok
 }
 
 // Listen starts the HTTP server, listening on the configured address, and binds all registered routes and middleware.
@@ -216,6 +261,9 @@ func (s *Server) listen(fn func() error) error {
 		errCh <- nil
 	}()
 	log.Println(banner(s.addr))
+	if s.printRoutes {
+		log.Printf("\n%s", s.routeTable())
+	}
 	return <-errCh
 }
 
@@ -336,18 +384,22 @@ func (s *Server) setAddr() error {
 
 func (s *Server) registerRoutes() {
 	registredCors := map[string]struct{}{}
+	if rt, ok := s.mux.(*router); ok {
+		rt.notFound = s.fallbackHandler(s.notFound, http.StatusNotFound)
+		rt.methodNotAllowed = s.fallbackHandler(s.methodNotAllowed, http.StatusMethodNotAllowed)
+	}
 	for _, route := range s.routes {
-		finalHandler := httpHandler(route.handler, route.pattern)
-		if !route.servingFiles {
-			finalHandler = registerMiddlewares(finalHandler, s.notFoundMiddleware)
+		// Explicit OPTIONS routes are kept over the CORS handler.
+		if method, endpoint := splitPattern(route.pattern); method == http.MethodOptions {
+			registredCors[endpoint] = struct{}{}
 		}
-		finalHandler = registerMiddlewares(finalHandler, s.globalMiddlewares...)
-		finalHandler = registerMiddlewares(finalHandler, route.middlewares...)
-		s.mux.Handle(route.pattern, finalHandler)
+	}
+	for _, route := range s.routes {
+		s.mux.Handle(route.pattern, s.routeHandler(route))
 		if s.corsEnabled {
 			parts := stringx.String(route.pattern).SplitN(stringx.Space.String(), 2)
 			if len(parts) < 2 {
-				return
+				continue
 			}
 			endpoint := parts[1]
 			if _, exists := registredCors[endpoint]; !exists {
@@ -359,103 +411,172 @@ func (s *Server) registerRoutes() {
 	}
 }
 
-var ErrPutAHandler = errors.New("put a handler")
+// routeHandler chains the route and global middlewares before the route handler.
+func (s *Server) routeHandler(route Router) http.Handler {
+	h := route.handler
+	limit := s.routeBodyLimit(route)
+	if limit > 0 {
+		h = withContentLengthLimit(h, limit)
+	}
+	middlewares := slices.Concat(route.middlewares, s.globalMiddlewares)
+	if s.recover != nil {
+		middlewares = slices.Insert(middlewares, 0, s.recover)
+	}
+	h = chain(h, middlewares...)
+	if limit > 0 {
+		h = withBodyLimit(h, limit)
+	}
+	if route.decoding != nil {
+		h = withDecoding(h, route.decoding)
+	}
+	h = withMultipart(h, route.multipart)
+	return httpHandler(h, route.pattern)
+}
+
+var (
+	ErrPutAHandler         = errors.New("put a handler")
+	ErrPutAMethod          = errors.New("put a method")
+	ErrDuplicatedRouteName = errors.New("route name already registered")
+)
 
 func (s *Server) registerRoute(r Router) error {
 	s.routes = append(s.routes, r)
 	return nil
 }
 
+func (s *Server) registerName(name, endpoint string) error {
+	if name == "" {
+		return nil
+	}
+	if _, exists := s.names[name]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicatedRouteName, name)
+	}
+	s.names[name] = s.transformPath(endpoint)
+	return nil
+}
+
 func (s *Server) routePattern(method, path string) string {
+	if method == "" {
+		return s.transformPath(path)
+	}
 	return fmt.Sprintf("%s %s", method, s.transformPath(path))
 }
 
-func (s *Server) transformPath(path string) string {
-	reParam := regexp.MustCompile(`:(\w+)`)
-	reSlash := regexp.MustCompile(`/+`)
+var (
+	reParam    = regexp.MustCompile(`:(\w+)(<[^>]*>)?(\?)?`)
+	reCatchAll = regexp.MustCompile(`/\*(\w+)`)
+	reSlash    = regexp.MustCompile(`/+`)
+)
 
-	path = reParam.ReplaceAllString(path, `{$1}`)
+func (s *Server) transformPath(path string) string {
+	path = reParam.ReplaceAllString(path, `{$1$2$3}`)
+	path = reCatchAll.ReplaceAllString(path, `/{$1...}`)
 	path = reSlash.ReplaceAllString(path, `/`)
 
 	return path
 }
 
 // Get registers a route for handling GET requests at the specified endpoint.
+// GET routes also answer HEAD requests with an empty body.
 func (s *Server) Get(endpoint string, handlers ...any) error {
-	handler, middlewares, err := registerHandlers(handlers...)
-	if err != nil {
-		return err
-	}
+	return s.Match([]string{http.MethodGet}, endpoint, handlers...)
+}
 
-	r := Router{
-		pattern:     s.routePattern(http.MethodGet, endpoint),
-		handler:     handler,
-		middlewares: middlewares,
-	}
-	return s.registerRoute(r)
+// Head registers a route for HEAD requests at the specified endpoint.
+func (s *Server) Head(endpoint string, handlers ...any) error {
+	return s.Match([]string{http.MethodHead}, endpoint, handlers...)
 }
 
 // Post registers a route for POST requests at the specified endpoint.
 func (s *Server) Post(endpoint string, handlers ...any) error {
-	handler, middlewares, err := registerHandlers(handlers...)
-	if err != nil {
-		return err
-	}
-
-	r := Router{
-		pattern:     s.routePattern(http.MethodPost, endpoint),
-		handler:     handler,
-		middlewares: middlewares,
-	}
-	return s.registerRoute(r)
+	return s.Match([]string{http.MethodPost}, endpoint, handlers...)
 }
 
 // Put registers a route for PUT requests at the specified endpoint.
 func (s *Server) Put(endpoint string, handlers ...any) error {
-	handler, middlewares, err := registerHandlers(handlers...)
-	if err != nil {
-		return err
-	}
-
-	r := Router{
-		pattern:     s.routePattern(http.MethodPut, endpoint),
-		handler:     handler,
-		middlewares: middlewares,
-	}
-	return s.registerRoute(r)
+	return s.Match([]string{http.MethodPut}, endpoint, handlers...)
 }
 
 // Patch registers a route for PATCH requests at the specified endpoint.
 func (s *Server) Patch(endpoint string, handlers ...any) error {
-	handler, middlewares, err := registerHandlers(handlers...)
-	if err != nil {
-		return err
-	}
-
-	r := Router{
-		pattern:     s.routePattern(http.MethodPatch, endpoint),
-		handler:     handler,
-		middlewares: middlewares,
-	}
-	return s.registerRoute(r)
+	return s.Match([]string{http.MethodPatch}, endpoint, handlers...)
 }
 
 // Delete registers a route for DELETE requests at the specified endpoint.
 func (s *Server) Delete(endpoint string, handlers ...any) error {
+	return s.Match([]string{http.MethodDelete}, endpoint, handlers...)
+}
+
+// Options registers a route for OPTIONS requests at the specified endpoint.
+func (s *Server) Options(endpoint string, handlers ...any) error {
+	return s.Match([]string{http.MethodOptions}, endpoint, handlers...)
+}
+
+// Any registers a route for every standard HTTP method at the specified endpoint.
+func (s *Server) Any(endpoint string, handlers ...any) error {
+	return s.Match(anyMethods, endpoint, handlers...)
+}
+
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// Match registers a route for each of the given methods at the specified endpoint.
+func (s *Server) Match(methods []string, endpoint string, handlers ...any) error {
+	return s.handle(methods, []string{endpoint}, handlers...)
+}
+
+// handle registers a route for each of the given methods at every endpoint.
+// The route name, if any, refers to the first endpoint.
+func (s *Server) handle(methods, endpoints []string, handlers ...any) error {
+	if len(methods) == 0 {
+		return ErrPutAMethod
+	}
+	options, handlers := routeOptions(handlers)
 	handler, middlewares, err := registerHandlers(handlers...)
 	if err != nil {
 		return err
 	}
 
-	r := Router{
-		pattern:     s.routePattern(http.MethodDelete, endpoint),
+	route := Router{
 		handler:     handler,
+		handlerName: handlerName(handlers[len(handlers)-1]),
 		middlewares: middlewares,
 	}
-	return s.registerRoute(r)
+	for _, option := range options {
+		option(&route)
+	}
+	for _, endpoint := range endpoints {
+		if err := validatePattern(s.transformPath(route.host + endpoint)); err != nil {
+			return err
+		}
+	}
+	if err := s.registerName(route.name, route.host+endpoints[0]); err != nil {
+		return err
+	}
+	for _, endpoint := range endpoints {
+		for _, method := range methods {
+			r := route
+			r.pattern = s.routePattern(method, route.host+endpoint)
+			if err := s.registerRoute(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // Use adds a global middleware to the server's middleware stack.
+// Global middlewares run after the middlewares of the route and
+// its groups, right before the route handler.
 func (s *Server) Use(middlewares ...any) error {
 	for _, middleware := range middlewares {
 		handler, err := validateHandler(middleware)
@@ -467,13 +588,6 @@ func (s *Server) Use(middlewares ...any) error {
 	return nil
 }
 
-func registerMiddlewares(handler http.Handler, middlewares ...Handler) http.Handler {
-	for i := len(middlewares) - 1; i >= 0; i-- {
-		handler = httpMiddleware(middlewares[i], handler)
-	}
-	return handler
-}
-
 // HandlerTester is an interface that represents a handler that can be tested.
 type HandlerTester interface {
 	// Handler returns the handler to be tested.
@@ -494,7 +608,7 @@ type TestServer struct {
 //	})
 //	testServer := server.Test()
 func (s *Server) Test() *TestServer {
-	s.mux = http.NewServeMux()
+	s.mux = s.newRouter()
 	return &TestServer{HandlerTester: s}
 }
 
@@ -517,36 +631,13 @@ func (t *TestServer) Request(r *http.Request) *httptest.ResponseRecorder {
 	return w
 }
 
-func httpMiddleware(m Handler, next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		req := NewRequest(r)
-		res := NewResponse(w)
-		if err := m(&req, &res); err != nil {
-			if srvErr, ok := err.(*Error); ok && srvErr != nil {
-				srvErr.ServeHTTP(w, r)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if !res.Sent() {
-			next.ServeHTTP(w, r)
-		}
-	})
-}
-
 func httpHandlerWithContext(h HandlerWithContext, pattern string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		req := NewRequest(r, pattern)
 		res := NewResponse(w)
 		handlerWithContext := h.Handler(&req, &res)
 		if err := handlerWithContext(&req, &res); err != nil {
-			if srvErr, ok := err.(*Error); ok && srvErr != nil {
-				srvErr.ServeHTTP(w, r)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			handleError(&req, &res, err)
 		}
 	})
 }
@@ -556,12 +647,7 @@ func httpHandler(h Handler, pattern string) http.Handler {
 		req := NewRequest(r, pattern)
 		res := NewResponse(w)
 		if err := h(&req, &res); err != nil {
-			if srvErr, ok := err.(*Error); ok && srvErr != nil {
-				srvErr.ServeHTTP(w, r)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			handleError(&req, &res, err)
 		}
 	})
 }
@@ -581,6 +667,12 @@ func ServeFiles(path http.FileSystem) Handler {
 			filePath += "index.html"
 		}
 		file, err := path.Open(filePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Error{
+				StatusCode: http.StatusNotFound,
+				Err:        errors.New(http.StatusText(http.StatusNotFound)),
+			}
+		}
 		if err != nil {
 			return err
 		}
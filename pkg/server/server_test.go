@@ -165,6 +165,71 @@ func assertServerError(t *testing.T, contentType, errMessage string) {
 	}
 }
 
+func TestServerErrorHandler(t *testing.T) {
+	errConflict := errors.New("user already exists")
+	notFound := &Error{StatusCode: http.StatusNotFound, Err: errors.New("user not found")}
+	handlers := map[string]any{
+		"/wrapped": func(c *Context) error {
+			return fmt.Errorf("load user: %w", notFound)
+		},
+		"/conflict": func(req *Request, res *Response) error {
+			return fmt.Errorf("create user: %w", errConflict)
+		},
+		"/plain": func(c *Context) error {
+			return errors.New("boom")
+		},
+	}
+
+	s := New(0)
+	for path, handler := range handlers {
+		s.Get(path, handler)
+	}
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/wrapped", nil))
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Equal(t, w.Body.String(), "user not found\n")
+	w = s.Test().Request(httptest.NewRequest(http.MethodGet, "/plain", nil))
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+	assert.Equal(t, w.Body.String(), "boom\n")
+
+	var handled []string
+	s = New(0, ServerOpts{
+		ErrorHandler: func(c *Context, err error) {
+			handled = append(handled, c.Path())
+			if errors.Is(err, errConflict) {
+				c.Status(http.StatusConflict).JSON(JSON{"error": err.Error()})
+				return
+			}
+			DefaultErrorHandler(c, err)
+		},
+	})
+	s.Use(func(c *Context) error {
+		if c.Query("token") == "" {
+			return &Error{StatusCode: http.StatusUnauthorized, Err: errors.New("missing token")}
+		}
+		return nil
+	})
+	for path, handler := range handlers {
+		s.Get(path, handler)
+	}
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/conflict?token=1", http.StatusConflict, `{"error":"create user: user already exists"}` + "\n"},
+		{"/wrapped?token=1", http.StatusNotFound, "user not found\n"},
+		{"/plain?token=1", http.StatusInternalServerError, "boom\n"},
+		{"/plain", http.StatusUnauthorized, "missing token\n"},
+		{"/unknown?token=1", http.StatusNotFound, "Not Found\n"},
+	}
+	for _, tt := range tests {
+		w := s.Test().Request(httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, w.Code, tt.code, tt.path)
+		assert.Equal(t, w.Body.String(), tt.body, tt.path)
+	}
+	assert.Equal(t, handled, []string{"/conflict", "/wrapped", "/plain", "/plain", "/unknown"})
+}
+
 func TestRegisterRouteErr(t *testing.T) {
 	server := New(9819371)
 	if err := server.Get("/"); err != ErrPutAHandler {
@@ -182,6 +247,65 @@ func TestRegisterRouteErr(t *testing.T) {
 	if err := server.Delete("/"); err != ErrPutAHandler {
 		t.Fatalf("result: %v expected: %v", err, ErrPutAHandler)
 	}
+	if err := server.Head("/"); err != ErrPutAHandler {
+		t.Fatalf("result: %v expected: %v", err, ErrPutAHandler)
+	}
+	if err := server.Options("/"); err != ErrPutAHandler {
+		t.Fatalf("result: %v expected: %v", err, ErrPutAHandler)
+	}
+	if err := server.Any("/"); err != ErrPutAHandler {
+		t.Fatalf("result: %v expected: %v", err, ErrPutAHandler)
+	}
+	handler := func(c *Context) error { return nil }
+	if err := server.Match(nil, "/", handler); err != ErrPutAMethod {
+		t.Fatalf("result: %v expected: %v", err, ErrPutAMethod)
+	}
+}
+
+func TestRouteMethods(t *testing.T) {
+	server := New(0)
+	echo := func(c *Context) error {
+		return c.SendString(c.Method())
+	}
+	server.Get("/hello", echo)
+	server.Head("/explicit", func(c *Context) error {
+		c.SetHeader("X-Explicit", "true")
+		return c.SendStatus(http.StatusOK)
+	})
+	server.Options("/hello", func(c *Context) error {
+		c.SetHeader("Allow", "GET, OPTIONS")
+		return c.SendStatus(http.StatusNoContent)
+	})
+	server.Any("/any", echo)
+	server.Group("/api").Match([]string{http.MethodPut, http.MethodPatch}, "/match", echo)
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		return server.Test().Request(httptest.NewRequest(method, path, nil))
+	}
+
+	w := request(http.MethodHead, "/hello")
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Empty(t, w.Body.String())
+
+	w = request(http.MethodHead, "/explicit")
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Header().Get("X-Explicit"), "true")
+
+	w = request(http.MethodOptions, "/hello")
+	assert.Equal(t, w.Code, http.StatusNoContent)
+	assert.Equal(t, w.Header().Get("Allow"), "GET, OPTIONS")
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodTrace} {
+		w = request(method, "/any")
+		assert.Equal(t, w.Code, http.StatusOK)
+		assert.Equal(t, w.Body.String(), method)
+	}
+
+	w = request(http.MethodPatch, "/api/match")
+	assert.Equal(t, w.Body.String(), http.MethodPatch)
+	w = request(http.MethodGet, "/api/match")
+	assert.Equal(t, w.Code, http.StatusMethodNotAllowed)
+	assert.Equal(t, w.Header().Get("Allow"), "PATCH, PUT")
 }
 
 func TestPort(t *testing.T) {
@@ -277,7 +401,7 @@ func TestMiddleware(t *testing.T) {
 		return res.Send([]byte(message))
 	}
 
-	finalHandler := httpMiddleware(middleware, httpHandler(handler, "/"))
+	finalHandler := httpHandler(chain(handler, middleware), "/")
 
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	w := httptest.NewRecorder()
@@ -300,7 +424,7 @@ func TestMiddleware(t *testing.T) {
 	middleware = func(req *Request, res *Response) error {
 		return err
 	}
-	finalHandler = httpMiddleware(middleware, httpHandler(handler, "/"))
+	finalHandler = httpHandler(chain(handler, middleware), "/")
 	w = httptest.NewRecorder()
 	req = httptest.NewRequest(http.MethodGet, "/", nil)
 	finalHandler.ServeHTTP(w, req)
@@ -315,7 +439,7 @@ func TestMiddleware(t *testing.T) {
 	middleware = func(req *Request, res *Response) error {
 		return err.Err
 	}
-	finalHandler = httpMiddleware(middleware, httpHandler(handler, "/"))
+	finalHandler = httpHandler(chain(handler, middleware), "/")
 	w = httptest.NewRecorder()
 	req = httptest.NewRequest(http.MethodGet, "/", nil)
 	finalHandler.ServeHTTP(w, req)
@@ -324,6 +448,66 @@ func TestMiddleware(t *testing.T) {
 	}
 }
 
+func TestMiddlewareNext(t *testing.T) {
+	var steps []string
+	var downstreamErr error
+	var status int
+	s := New(0)
+	s.Use(func(c *Context) error {
+		steps = append(steps, "logger:before")
+		err := c.Next()
+		downstreamErr = err
+		status = c.StatusCode()
+		steps = append(steps, "logger:after")
+		return err
+	})
+	s.Use(func(req *Request, res *Response) error {
+		steps = append(steps, "legacy")
+		return nil
+	})
+	tx := func(c *Context) error {
+		steps = append(steps, "tx:begin")
+		if err := c.Next(); err != nil {
+			steps = append(steps, "tx:rollback")
+			return err
+		}
+		steps = append(steps, "tx:commit")
+		return nil
+	}
+	s.Post("/users", tx, func(c *Context) error {
+		steps = append(steps, "handler")
+		if c.Query("fail") != "" {
+			return &Error{StatusCode: http.StatusConflict, Err: errors.New("conflict")}
+		}
+		return c.Status(http.StatusCreated).SendString("created")
+	})
+
+	w := s.Test().Request(httptest.NewRequest(http.MethodPost, "/users", nil))
+	assert.Equal(t, w.Code, http.StatusCreated)
+	assert.Equal(t, steps, []string{"tx:begin", "logger:before", "legacy", "handler", "logger:after", "tx:commit"})
+	assert.Nil(t, downstreamErr)
+
+	steps = nil
+	w = s.Test().Request(httptest.NewRequest(http.MethodPost, "/users?fail=1", nil))
+	assert.Equal(t, w.Code, http.StatusConflict)
+	assert.Equal(t, status, http.StatusConflict)
+	assert.Equal(t, steps, []string{"tx:begin", "logger:before", "legacy", "handler", "logger:after", "tx:rollback"})
+	assert.NotNil(t, downstreamErr)
+
+	twice := chain(func(req *Request, res *Response) error {
+		steps = append(steps, "handler")
+		return nil
+	}, func(req *Request, res *Response) error {
+		req.Next()
+		return req.Next()
+	})
+	steps = nil
+	req := NewRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+	res := NewResponse(httptest.NewRecorder())
+	assert.NoError(t, twice(&req, &res))
+	assert.Equal(t, steps, []string{"handler"})
+}
+
 func TestServeFiles(t *testing.T) {
 	dirPath := t.TempDir()
 	initTestCase := func() (
@@ -428,6 +612,13 @@ func TestTransformPath(t *testing.T) {
 	result = server.transformPath("/user/:id/posts//:name")
 	expected = "/user/{id}/posts/{name}"
 	assert.Equal(t, result, expected)
+	result = server.transformPath("/user/:id<int>/posts/:slug<[a-z-]+>")
+	expected = "/user/{id<int>}/posts/{slug<[a-z-]+>}"
+	assert.Equal(t, result, expected)
+	result = server.transformPath("/posts/:id?")
+	assert.Equal(t, result, "/posts/{id?}")
+	result = server.transformPath("/files/*path")
+	assert.Equal(t, result, "/files/{path...}")
 }
 
 func TestTestServer(t *testing.T) {
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"io"
+	"iter"
+	"net/http"
+	"time"
+
+	"github.com/i9si-sistemas/nine/internal/json"
+)
+
+// BodyReader reads the body of a request as it arrives.
+type BodyReader struct {
+	io.ReadCloser
+	rc *http.ResponseController
+}
+
+// SetReadDeadline sets the deadline for reading the rest of the body.
+// A zero value means no deadline. It returns an error wrapping
+// http.ErrNotSupported when the server does not support deadlines.
+func (b *BodyReader) SetReadDeadline(deadline time.Time) error {
+	return b.rc.SetReadDeadline(deadline)
+}
+
+// BodyStream returns the body of the request as it arrives, without
+// buffering it, to process large uploads. The BodyLimit of the route
+// still applies.
+//
+//	body := c.BodyStream()
+//	body.SetReadDeadline(time.Now().Add(time.Minute))
+//	_, err := io.Copy(file, body)
+//
+// The body must be read either as a stream or through the helpers
+// buffering it, such as Body and BodyParser.
+func (c *Context) BodyStream() *BodyReader {
+	body := c.Request.HTTP().Body
+	if body == nil {
+		body = http.NoBody
+	}
+	return &BodyReader{
+		ReadCloser: body,
+		rc:         http.NewResponseController(c.Response.HTTP()),
+	}
+}
+
+// JSONArray returns the elements of the JSON array sent as body, decoded
+// and validated one by one as they arrive. The iteration stops at the
+// first decoding error; the other errors are *ValidationError values
+// describing the element yielded with them.
+//
+//	for user, err := range i9.JSONArray[User](c) {
+//		if err != nil {
+//			return err
+//		}
+//		users.Insert(user)
+//	}
+//
+// The body is decoded with the DecodeOptions of the route.
+func JSONArray[T any](c *Context) iter.Seq2[T, error] {
+	return validateItems(json.Items[T](c.BodyStream(), c.jsonOptions()))
+}
+
+// NDJSON returns the values of the newline delimited JSON sent as body,
+// such as application/x-ndjson, decoded and validated one by one as they
+// arrive. Errors are yielded as by JSONArray.
+func NDJSON[T any](c *Context) iter.Seq2[T, error] {
+	return validateItems(json.Lines[T](c.BodyStream(), c.jsonOptions()))
+}
+
+// jsonOptions returns the DecodeOptions of the route for JSON decoders.
+func (c *Context) jsonOptions() json.DecoderOptions {
+	opts := c.Request.decodeOptions()
+	return json.DecoderOptions{
+		DisallowUnknownFields: opts.DisallowUnknownFields,
+		UseNumber:             opts.UseNumber,
+		SingleValue:           opts.SingleValue,
+	}
+}
+
+func validateItems[T any](items iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range items {
+			if err != nil {
+				yield(item, bodyError(err))
+				return
+			}
+			if !yield(item, Validate(&item)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+type streamUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+func TestJSONArray(t *testing.T) {
+	s := New(0)
+	received := make(chan int, 1)
+	s.Post("/users", func(c *Context) error {
+		var names []string
+		for user, err := range JSONArray[streamUser](c) {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				names = append(names, "invalid")
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			names = append(names, user.Name)
+			if user.ID == 1 {
+				received <- user.ID
+			}
+		}
+		return c.SendString(strings.Join(names, ","))
+	})
+
+	body, w := io.Pipe()
+	go func() {
+		io.WriteString(w, `[{"id":1,"name":"gopher"}`)
+		// The first user is handled before the rest of the body is sent.
+		<-received
+		io.WriteString(w, `,{"id":2},{"id":3,"name":"gordon"}]`)
+		w.Close()
+	}()
+	res := s.Test().Request(httptest.NewRequest(http.MethodPost, "/users", body))
+	assert.Equal(t, res.Code, http.StatusOK)
+	assert.Equal(t, res.Body.String(), "gopher,invalid,gordon")
+
+	res = s.Test().Request(httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`[{"id":1,"name":"gopher"},{"id":"2"}]`)))
+	assert.Equal(t, res.Code, http.StatusBadRequest)
+}
+
+func TestNDJSON(t *testing.T) {
+	s := New(0, ServerOpts{BodyLimit: 64})
+	s.Post("/events", func(c *Context) error {
+		count := 0
+		for _, err := range NDJSON[map[string]any](c) {
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		return c.JSON(JSON{"count": count})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader("{\"type\":\"a\"}\n{\"type\":\"b\"}\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	res := s.Test().Request(req)
+	assert.Equal(t, res.Body.String(), `{"count":2}`+"\n")
+
+	req = httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("{\"type\":\"a\"}\n", 10)))
+	req.ContentLength = -1
+	res = s.Test().Request(req)
+	assert.Equal(t, res.Code, http.StatusRequestEntityTooLarge)
+}
+
+func TestBodyStream(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("streamed body"))
+	c := NewContext(req.Context(), req, httptest.NewRecorder())
+	body := c.BodyStream()
+	err := body.SetReadDeadline(time.Now().Add(time.Second))
+	assert.True(t, errors.Is(err, http.ErrNotSupported))
+	b, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), "streamed body")
+	assert.NoError(t, body.Close())
+}
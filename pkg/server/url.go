@@ -0,0 +1,101 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrRouteNameNotFound  = errors.New("route name not found")
+	ErrMissingRouteParam  = errors.New("missing route param")
+	ErrInvalidRouteParams = errors.New("route params must be key/value pairs")
+	ErrInvalidRouteParam  = errors.New("invalid route param")
+	ErrServerNotFound     = errors.New("server not found in request context")
+)
+
+// URL rebuilds the path of the route registered with the given name.
+//
+// Params are given as key/value pairs. Keys matching a path parameter
+// replace it with the escaped value, the remaining ones are appended
+// as query parameters. Only the path is rebuilt: for routes registered
+// on a Host, the keys matching a host parameter are ignored.
+//
+//	server.Get("/users/:id", showUser, i9.Name("users.show"))
+//	url, err := server.URL("users.show", "id", 42, "tab", "posts")
+//	// url == "/users/42?tab=posts"
+func (s *Server) URL(name string, params ...any) (string, error) {
+	pattern, ok := s.names[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrRouteNameNotFound, name)
+	}
+	if len(params)%2 != 0 {
+		return "", ErrInvalidRouteParams
+	}
+	values := make(url.Values, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		key := fmt.Sprint(params[i])
+		values.Add(key, fmt.Sprint(params[i+1]))
+	}
+	host, pattern := splitHost(pattern)
+	for _, name := range hostParams(host) {
+		values.Del(name)
+	}
+
+	segments := pathSegments(pattern)
+	for i, segment := range segments {
+		name, expr, kind := parseSegment(segment)
+		if kind == segmentStatic {
+			continue
+		}
+		if !values.Has(name) {
+			if kind == segmentOptional {
+				segments = segments[:i]
+				break
+			}
+			return "", fmt.Errorf("%w: %s", ErrMissingRouteParam, name)
+		}
+		value := values.Get(name)
+		values.Del(name)
+		if kind == segmentWildcard {
+			segments[i] = escapeWildcard(value)
+			continue
+		}
+		if expr != "" {
+			if c, err := compileConstraint(expr); err != nil || !c(value) {
+				return "", fmt.Errorf("%w: %s=%q does not match <%s>", ErrInvalidRouteParam, name, value, expr)
+			}
+		}
+		segments[i] = url.PathEscape(value)
+	}
+
+	if len(segments) == 0 {
+		segments = []string{""}
+	}
+	path := "/" + strings.Join(segments, "/")
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+	return path, nil
+}
+
+func escapeWildcard(value string) string {
+	parts := strings.Split(value, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// URLFor rebuilds the path of the route registered with the given name
+// on the server handling the request. See Server.URL.
+//
+//	location, err := c.URLFor("users.show", "id", user.ID)
+func (c *Context) URLFor(name string, params ...any) (string, error) {
+	s, ok := serverFromContext(c.Request.Context())
+	if !ok {
+		return "", ErrServerNotFound
+	}
+	return s.URL(name, params...)
+}
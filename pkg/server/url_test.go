@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+func TestURL(t *testing.T) {
+	s := New(0)
+	handler := func(c *Context) error { return nil }
+	assert.NoError(t, s.Get("/users/:id", handler, Name("users.show")))
+	assert.NoError(t, s.Group("/api").Match(
+		[]string{http.MethodPut, http.MethodPatch},
+		"/posts/{slug}/comments/:comment",
+		handler,
+		Name("comments.update"),
+	))
+	assert.NoError(t, s.Get("/files/{path...}", handler, Name("files")))
+	assert.NoError(t, s.Get("/orders/:id<int>", handler, Name("orders.show")))
+
+	err := s.Post("/users", handler, Name("users.show"))
+	assert.True(t, errors.Is(err, ErrDuplicatedRouteName))
+
+	url, err := s.URL("users.show", "id", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/users/42")
+
+	url, err = s.URL("comments.update", "slug", "hello world", "comment", 7, "page", 2, "sort", "desc")
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/api/posts/hello%20world/comments/7?page=2&sort=desc")
+
+	url, err = s.URL("files", "path", "docs/read me.md")
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/files/docs/read%20me.md")
+
+	url, err = s.URL("orders.show", "id", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/orders/7")
+	_, err = s.URL("orders.show", "id", "seven")
+	assert.True(t, errors.Is(err, ErrInvalidRouteParam))
+
+	_, err = s.URL("unknown")
+	assert.True(t, errors.Is(err, ErrRouteNameNotFound))
+	_, err = s.URL("users.show")
+	assert.True(t, errors.Is(err, ErrMissingRouteParam))
+	_, err = s.URL("users.show", "id")
+	assert.True(t, errors.Is(err, ErrInvalidRouteParams))
+}
+
+func TestContextURLFor(t *testing.T) {
+	s := New(0)
+	s.Get("/users/:id", func(c *Context) error {
+		return c.SendString(c.Param("id"))
+	}, Name("users.show"))
+	s.Get("/me", func(c *Context) error {
+		url, err := c.URLFor("users.show", "id", "gopher")
+		if err != nil {
+			return err
+		}
+		return c.SendString(url)
+	})
+	w := s.Test().Request(httptest.NewRequest(http.MethodGet, "/me", nil))
+	assert.Equal(t, w.Body.String(), "/users/gopher")
+
+	c := NewContext(t.Context(), httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	_, err := c.URLFor("users.show", "id", 1)
+	assert.Equal(t, err, ErrServerNotFound)
+}
+
+func TestURLHost(t *testing.T) {
+	s := New(0)
+	tenant := s.Host(":tenant.example.com")
+	assert.NoError(t, tenant.Get("/users/:id", func(c *Context) error { return nil }, Name("tenant.users")))
+
+	url, err := s.URL("tenant.users", "tenant", "acme", "id", 1, "tab", "posts")
+	assert.NoError(t, err)
+	assert.Equal(t, url, "/users/1?tab=posts")
+}
@@ -0,0 +1,395 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Validate checks the struct pointed by v against the rules of its
+// validate tags, returning a *ValidationError listing every failing field.
+// It is called by Bind, BodyParser and Body once the request values are
+// decoded. ParamsParser, QueryParser and ReqHeaderParser fill only part
+// of a struct and do not validate it, so a struct filled from several
+// sources is validated once, by BodyParser or by calling Validate.
+//
+//	var body struct {
+//		Name  string   `json:"name" validate:"required,min=3,max=50"`
+//		Email string   `json:"email" validate:"required,email"`
+//		Role  string   `json:"role" validate:"omitempty,oneof=admin user"`
+//		Tags  []string `json:"tags" validate:"max=5"`
+//	}
+//
+// The supported rules are:
+//
+//   - required: the value is not the zero value, nil or empty
+//   - omitempty: the other rules are skipped when the value is empty
+//   - min=n, max=n, len=n: bounds of numbers, and of the length of
+//     strings, slices and maps
+//   - email: the string is an email address, such as "gopher@go.dev"
+//   - url: the string is an absolute URL
+//   - oneof=a b: the value is one of the space separated values
+//
+// Nested structs, and slices and maps of structs, are validated too.
+// Fields are reported by their JSON path, such as "items[0].name".
+// Values which are not structs have no rules and are always valid.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	validationErr := new(ValidationError)
+	if err := validateStruct(rv, "", validationErr); err != nil {
+		return err
+	}
+	if len(validationErr.Errors) > 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// ValidationError holds the fields breaking their validate rules.
+// It is replied with 422 Unprocessable Entity by DefaultErrorHandler,
+// listing the fields under the "errors" key of JSON and XML responses.
+type ValidationError struct {
+	Errors []*Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, violation := range e.Errors {
+		messages[i] = violation.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// HTTPStatus returns the status code replied for the error.
+func (e *ValidationError) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// Violation describes a field breaking one of its validate rules.
+type Violation struct {
+	// Field is the JSON path of the field, such as "address.city".
+	Field string `json:"field" xml:"field"`
+	// Rule is the name of the broken rule, such as "min".
+	Rule    string `json:"rule" xml:"rule"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+func (v *Violation) Error() string {
+	return v.Field + " " + v.Message
+}
+
+type validationPlan struct {
+	fields []validationField
+	err    error
+}
+
+type validationField struct {
+	index  []int
+	name   string
+	rules  []validationRule
+	nested bool
+}
+
+type validationRule struct {
+	name  string
+	param string
+	check func(v reflect.Value, param string) (message string, ok bool)
+}
+
+var validationPlans sync.Map
+
+// validationPlanOf returns the cached validation plan of the struct type.
+func validationPlanOf(t reflect.Type) *validationPlan {
+	if plan, ok := validationPlans.Load(t); ok {
+		return plan.(*validationPlan)
+	}
+	plan := new(validationPlan)
+	plan.err = plan.add(t, nil)
+	actual, _ := validationPlans.LoadOrStore(t, plan)
+	return actual.(*validationPlan)
+}
+
+func (p *validationPlan) add(t reflect.Type, index []int) error {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+		if embedded := embeddedStruct(sf); embedded != nil {
+			if err := p.add(embedded, fieldIndex); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		rules, err := parseRules(sf)
+		if err != nil {
+			return err
+		}
+		nested := hasStructs(sf.Type)
+		if len(rules) == 0 && !nested {
+			continue
+		}
+		p.fields = append(p.fields, validationField{
+			index:  fieldIndex,
+			name:   jsonName(sf),
+			rules:  rules,
+			nested: nested,
+		})
+	}
+	return nil
+}
+
+// embeddedStruct returns the struct type of an embedded field whose
+// fields are promoted in JSON documents, or nil.
+func embeddedStruct(sf reflect.StructField) reflect.Type {
+	t := sf.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if !sf.Anonymous || t.Kind() != reflect.Struct || sf.Tag.Get("json") != "" {
+		return nil
+	}
+	return t
+}
+
+// jsonName returns the name of the field in JSON documents, or the key
+// of the request value it is bound from when it has no json tag.
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name != "" && name != "-" {
+		return name
+	}
+	if _, key := bindTag(sf.Tag); key != "" {
+		return key
+	}
+	return sf.Name
+}
+
+// hasStructs reports whether values of the type hold structs to validate.
+func hasStructs(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return !isTextUnmarshaler(t)
+		default:
+			return false
+		}
+	}
+}
+
+func parseRules(sf reflect.StructField) ([]validationRule, error) {
+	tag := sf.Tag.Get("validate")
+	if tag == "" || tag == "-" {
+		return nil, nil
+	}
+	t := sf.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var rules []validationRule
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		r := validationRule{name: name, param: param}
+		switch name {
+		case "required", "omitempty":
+		case "min", "max", "len":
+			if _, err := strconv.ParseFloat(param, 64); err != nil {
+				return nil, fmt.Errorf("validate: invalid %s parameter %q on field %s", name, param, sf.Name)
+			}
+			if _, ok := sizeOf(reflect.Zero(t)); !ok {
+				return nil, fmt.Errorf("validate: rule %s does not apply to field %s of type %s", name, sf.Name, sf.Type)
+			}
+			r.check = validators[name]
+		case "email", "url":
+			if t.Kind() != reflect.String {
+				return nil, fmt.Errorf("validate: rule %s does not apply to field %s of type %s", name, sf.Name, sf.Type)
+			}
+			r.check = validators[name]
+		case "oneof":
+			if param == "" {
+				return nil, fmt.Errorf("validate: missing oneof values on field %s", sf.Name)
+			}
+			r.check = validators[name]
+		default:
+			return nil, fmt.Errorf("validate: unknown rule %q on field %s", name, sf.Name)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+var validators = map[string]func(v reflect.Value, param string) (string, bool){
+	"min": func(v reflect.Value, param string) (string, bool) {
+		size, _ := sizeOf(v)
+		n, _ := strconv.ParseFloat(param, 64)
+		return "must " + sizeMessage(v, "be at least", param), size >= n
+	},
+	"max": func(v reflect.Value, param string) (string, bool) {
+		size, _ := sizeOf(v)
+		n, _ := strconv.ParseFloat(param, 64)
+		return "must " + sizeMessage(v, "be at most", param), size <= n
+	},
+	"len": func(v reflect.Value, param string) (string, bool) {
+		size, _ := sizeOf(v)
+		n, _ := strconv.ParseFloat(param, 64)
+		return "must " + sizeMessage(v, "be exactly", param), size == n
+	},
+	"email": func(v reflect.Value, _ string) (string, bool) {
+		addr, err := mail.ParseAddress(v.String())
+		return "must be a valid email address", err == nil && addr.Address == v.String()
+	},
+	"url": func(v reflect.Value, _ string) (string, bool) {
+		u, err := url.ParseRequestURI(v.String())
+		return "must be a valid URL", err == nil && u.Scheme != "" && u.Host != ""
+	},
+	"oneof": func(v reflect.Value, param string) (string, bool) {
+		values := strings.Fields(param)
+		message := "must be one of: " + strings.Join(values, ", ")
+		s := fmt.Sprint(v.Interface())
+		for _, value := range values {
+			if s == value {
+				return message, true
+			}
+		}
+		return message, false
+	},
+}
+
+// sizeOf returns the number compared by the min, max and len rules:
+// the value of numbers and the length of strings, slices and maps.
+func sizeOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func sizeMessage(v reflect.Value, bound, param string) string {
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%s %s characters long", bound, param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("contain %s %s items", strings.TrimPrefix(bound, "be "), param)
+	}
+	return bound + " " + param
+}
+
+func validateStruct(v reflect.Value, path string, validationErr *ValidationError) error {
+	plan := validationPlanOf(v.Type())
+	if plan.err != nil {
+		return plan.err
+	}
+	for _, f := range plan.fields {
+		field, err := v.FieldByIndexErr(f.index)
+		if err != nil {
+			// Fields promoted through a nil embedded pointer are not validated.
+			continue
+		}
+		name := f.name
+		if path != "" {
+			name = path + "." + name
+		}
+		if !validateField(field, name, f.rules, validationErr) {
+			continue
+		}
+		if f.nested {
+			if err := validateNested(field, name, validationErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateField applies the rules to the field, reporting whether its
+// nested values should be validated too.
+func validateField(field reflect.Value, name string, rules []validationRule, validationErr *ValidationError) bool {
+	empty := field.IsZero() || (field.Kind() == reflect.Slice || field.Kind() == reflect.Map) && field.Len() == 0
+	for field.Kind() == reflect.Pointer && !field.IsNil() {
+		field = field.Elem()
+	}
+	for _, rule := range rules {
+		switch rule.name {
+		case "required":
+			if empty {
+				validationErr.Errors = append(validationErr.Errors, &Violation{
+					Field:   name,
+					Rule:    rule.name,
+					Message: "is required",
+				})
+				return false
+			}
+		case "omitempty":
+			if empty {
+				return false
+			}
+		default:
+			if field.Kind() == reflect.Pointer {
+				return false
+			}
+			if message, ok := rule.check(field, rule.param); !ok {
+				validationErr.Errors = append(validationErr.Errors, &Violation{
+					Field:   name,
+					Rule:    rule.name,
+					Param:   rule.param,
+					Message: message,
+				})
+			}
+		}
+	}
+	return true
+}
+
+func validateNested(v reflect.Value, path string, validationErr *ValidationError) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return validateNested(v.Elem(), path, validationErr)
+	case reflect.Struct:
+		return validateStruct(v, path, validationErr)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := validateNested(v.Index(i), fmt.Sprintf("%s[%d]", path, i), validationErr); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := validateNested(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), validationErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/i9si-sistemas/assert"
+)
+
+type validateAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=8"`
+}
+
+type validateItem struct {
+	Name     string  `json:"name" validate:"required,max=10"`
+	Quantity int     `json:"quantity" validate:"min=1,max=99"`
+	Price    float64 `json:"price" validate:"min=0.01"`
+}
+
+type validateUser struct {
+	Name     string          `json:"name" validate:"required,min=3,max=50"`
+	Email    string          `json:"email" validate:"required,email"`
+	Role     string          `json:"role,omitempty" validate:"omitempty,oneof=admin user"`
+	Website  *string         `json:"website" validate:"omitempty,url"`
+	Age      *int            `json:"age" validate:"required,min=18"`
+	Tags     []string        `json:"tags" validate:"max=2"`
+	Address  validateAddress `json:"address"`
+	Items    []validateItem  `json:"items" validate:"required"`
+	Contacts map[string]*validateAddress
+}
+
+func TestValidate(t *testing.T) {
+	age, website := 30, "https://go.dev"
+	valid := validateUser{
+		Name:    "Gopher",
+		Email:   "gopher@go.dev",
+		Role:    "admin",
+		Website: &website,
+		Age:     &age,
+		Address: validateAddress{City: "São Paulo", Zip: "01001000"},
+		Items:   []validateItem{{Name: "book", Quantity: 2, Price: 9.9}},
+	}
+	assert.NoError(t, Validate(&valid))
+	assert.NoError(t, Validate(map[string]any{"name": ""}))
+	assert.NoError(t, Validate((*validateUser)(nil)))
+
+	invalid := valid
+	invalid.Name = "Go"
+	invalid.Email = "Gopher <gopher@go.dev>"
+	invalid.Role = "root"
+	invalid.Age = nil
+	invalid.Tags = []string{"a", "b", "c"}
+	invalid.Address = validateAddress{Zip: "123"}
+	invalid.Items = []validateItem{valid.Items[0], {Name: "notebooks!!", Quantity: 100}}
+	invalid.Contacts = map[string]*validateAddress{"home": {Zip: "01001000"}}
+
+	err := Validate(&invalid)
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	tests := []struct {
+		field, rule, message string
+	}{
+		{"name", "min", "must be at least 3 characters long"},
+		{"email", "email", "must be a valid email address"},
+		{"role", "oneof", "must be one of: admin, user"},
+		{"age", "required", "is required"},
+		{"tags", "max", "must contain at most 2 items"},
+		{"address.city", "required", "is required"},
+		{"address.zip", "len", "must be exactly 8 characters long"},
+		{"items[1].name", "max", "must be at most 10 characters long"},
+		{"items[1].quantity", "max", "must be at most 99"},
+		{"items[1].price", "min", "must be at least 0.01"},
+		{"Contacts[home].city", "required", "is required"},
+	}
+	assert.Equal(t, len(validationErr.Errors), len(tests))
+	for i, tt := range tests {
+		assert.Equal(t, validationErr.Errors[i].Field, tt.field)
+		assert.Equal(t, validationErr.Errors[i].Rule, tt.rule, tt.field)
+		assert.Equal(t, validationErr.Errors[i].Message, tt.message, tt.field)
+	}
+	assert.True(t, strings.HasPrefix(err.Error(), "name must be at least 3 characters long; email"))
+
+	var unknown struct {
+		Name string `validate:"required,uuid"`
+	}
+	assert.Equal(t, Validate(&unknown).Error(), `validate: unknown rule "uuid" on field Name`)
+	var mismatch struct {
+		Active bool `validate:"min=1"`
+	}
+	assert.Equal(t, Validate(&mismatch).Error(), "validate: rule min does not apply to field Active of type bool")
+}
+
+func TestValidateAfterBinding(t *testing.T) {
+	s := New(0)
+	s.Post("/users", func(c *Context) error {
+		var user validateUser
+		if err := c.BodyParser(&user); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusCreated)
+	})
+	s.Get("/search", func(c *Context) error {
+		var query struct {
+			Sort  string `query:"sort" default:"name" validate:"oneof=name date"`
+			Limit int    `query:"limit" default:"20" validate:"min=1,max=100"`
+		}
+		if err := c.Bind(&query); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	body := `{"name":"Gopher","email":"gopher@go.dev","age":30,"address":{"city":"Rio","zip":"20000000"},"items":[{"name":"pen","quantity":1,"price":1}]}`
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusCreated)
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Gopher","email":"gopher","age":30,"address":{"city":"Rio","zip":"20000000"}}`))
+	req.Header.Set("Accept", "application/json")
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusUnprocessableEntity)
+	assert.Equal(t, w.Body.String(), `{"err":"email must be a valid email address; items is required",`+
+		`"errors":[{"field":"email","rule":"email","message":"must be a valid email address"},`+
+		`{"field":"items","rule":"required","message":"is required"}]}`)
+
+	req = httptest.NewRequest(http.MethodGet, "/search?limit=500", nil)
+	req.Header.Set("Accept", "application/xml")
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusUnprocessableEntity)
+	assert.True(t, strings.Contains(w.Body.String(),
+		"<errors><field>limit</field><rule>max</rule><param>100</param><message>must be at most 100</message></errors>"))
+
+	req = httptest.NewRequest(http.MethodGet, "/search?sort=date", nil)
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+}
+
+func TestValidateMultipleSources(t *testing.T) {
+	s := New(0)
+	s.Put("/users/:id", func(c *Context) error {
+		var user struct {
+			ID   int    `param:"id" json:"-" validate:"min=1"`
+			Name string `json:"name" validate:"required"`
+		}
+		if err := c.ParamsParser(&user); err != nil {
+			return err
+		}
+		if err := c.BodyParser(&user); err != nil {
+			return err
+		}
+		return c.JSON(JSON{"id": user.ID, "name": user.Name})
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/users/7", strings.NewReader(`{"name":"gopher"}`))
+	w := s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), `{"id":7,"name":"gopher"}`+"\n")
+
+	req = httptest.NewRequest(http.MethodPut, "/users/0", strings.NewReader(`{}`))
+	req.Header.Set("Accept", "application/json")
+	w = s.Test().Request(req)
+	assert.Equal(t, w.Code, http.StatusUnprocessableEntity)
+	assert.True(t, strings.Contains(w.Body.String(), `"field":"name","rule":"required"`))
+	assert.True(t, strings.Contains(w.Body.String(), `"rule":"min"`))
+}
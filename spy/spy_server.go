@@ -3,6 +3,7 @@ package spy
 import (
 	"context"
 	"io/fs"
+	"net/http"
 	"sync"
 
 	i9 "github.com/i9si-sistemas/nine/pkg/server"
@@ -13,19 +14,27 @@ type Server struct {
 	mu *sync.Mutex
 
 	// Recorded method calls
-	UseCalls          []UseCall
-	GetCalls          []RouteCall
-	PostCalls         []RouteCall
-	PutCalls          []RouteCall
-	PatchCalls        []RouteCall
-	DeleteCalls       []RouteCall
-	RouteCalls        []RouteCall
-	GroupCalls        []GroupCall
-	ServeFilesCalls   []ServeFilesCall
-	TestCalls         int
-	ListenCalls       int
-	ShutdownCalls     []context.Context
-	CertFile, KeyFile string
+	UseCalls              []UseCall
+	GetCalls              []RouteCall
+	HeadCalls             []RouteCall
+	PostCalls             []RouteCall
+	PutCalls              []RouteCall
+	PatchCalls            []RouteCall
+	DeleteCalls           []RouteCall
+	OptionsCalls          []RouteCall
+	AnyCalls              []RouteCall
+	MatchCalls            []MatchCall
+	MountCalls            []MountCall
+	NotFoundCalls         []RouteCall
+	MethodNotAllowedCalls []RouteCall
+	RouteCalls            []RouteCall
+	GroupCalls            []GroupCall
+	HostCalls             []GroupCall
+	ServeFilesCalls       []ServeFilesCall
+	TestCalls             int
+	ListenCalls           int
+	ShutdownCalls         []context.Context
+	CertFile, KeyFile     string
 }
 
 type UseCall struct {
@@ -39,6 +48,20 @@ type RouteCall struct {
 	Err      error
 }
 
+type MatchCall struct {
+	Methods  []string
+	Path     string
+	Handlers []any
+	Err      error
+}
+
+type MountCall struct {
+	Prefix      string
+	Handler     http.Handler
+	Middlewares []any
+	Err         error
+}
+
 type GroupCall struct {
 	Prefix      string
 	Middlewares []any
@@ -54,19 +77,27 @@ type ServeFilesCall struct {
 // NewServer creates a new server Spy instance
 func NewServer() *Server {
 	return &Server{
-		mu:              new(sync.Mutex),
-		UseCalls:        []UseCall{},
-		GetCalls:        []RouteCall{},
-		PostCalls:       []RouteCall{},
-		PutCalls:        []RouteCall{},
-		PatchCalls:      []RouteCall{},
-		DeleteCalls:     []RouteCall{},
-		RouteCalls:      []RouteCall{},
-		GroupCalls:      []GroupCall{},
-		ServeFilesCalls: []ServeFilesCall{},
-		TestCalls:       0,
-		ListenCalls:     0,
-		ShutdownCalls:   []context.Context{},
+		mu:                    new(sync.Mutex),
+		UseCalls:              []UseCall{},
+		GetCalls:              []RouteCall{},
+		HeadCalls:             []RouteCall{},
+		PostCalls:             []RouteCall{},
+		PutCalls:              []RouteCall{},
+		PatchCalls:            []RouteCall{},
+		DeleteCalls:           []RouteCall{},
+		OptionsCalls:          []RouteCall{},
+		AnyCalls:              []RouteCall{},
+		MatchCalls:            []MatchCall{},
+		MountCalls:            []MountCall{},
+		NotFoundCalls:         []RouteCall{},
+		MethodNotAllowedCalls: []RouteCall{},
+		RouteCalls:            []RouteCall{},
+		GroupCalls:            []GroupCall{},
+		HostCalls:             []GroupCall{},
+		ServeFilesCalls:       []ServeFilesCall{},
+		TestCalls:             0,
+		ListenCalls:           0,
+		ShutdownCalls:         []context.Context{},
 	}
 }
 
@@ -95,6 +126,19 @@ func (s *Server) Get(path string, handlers ...any) error {
 	return err
 }
 
+func (s *Server) Head(path string, handlers ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.HeadCalls = append(s.HeadCalls, RouteCall{
+		Path:     path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
 func (s *Server) Post(path string, handlers ...any) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -147,6 +191,86 @@ func (s *Server) Delete(path string, handlers ...any) error {
 	return err
 }
 
+func (s *Server) Options(path string, handlers ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.OptionsCalls = append(s.OptionsCalls, RouteCall{
+		Path:     path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (s *Server) Any(path string, handlers ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.AnyCalls = append(s.AnyCalls, RouteCall{
+		Path:     path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (s *Server) Match(methods []string, path string, handlers ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.MatchCalls = append(s.MatchCalls, MatchCall{
+		Methods:  methods,
+		Path:     path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (s *Server) Mount(prefix string, h http.Handler, middlewares ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.MountCalls = append(s.MountCalls, MountCall{
+		Prefix:      prefix,
+		Handler:     h,
+		Middlewares: middlewares,
+		Err:         err,
+	})
+	return err
+}
+
+func (s *Server) NotFound(handlers ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.NotFoundCalls = append(s.NotFoundCalls, RouteCall{
+		Path:     "",
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (s *Server) MethodNotAllowed(handlers ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := error(nil)
+	s.MethodNotAllowedCalls = append(s.MethodNotAllowedCalls, RouteCall{
+		Path:     "",
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
 func (s *Server) Route(prefix string, fn func(i9.RouteManager)) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -184,6 +308,23 @@ func (s *Server) Group(prefix string, middlewares ...any) i9.RouteManager {
 	return group
 }
 
+func (s *Server) Host(pattern string, middlewares ...any) i9.RouteManager {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := &RouteGroup{
+		parent: s,
+		Server: s,
+		mu:     s.mu,
+	}
+	s.HostCalls = append(s.HostCalls, GroupCall{
+		Prefix:      pattern,
+		Middlewares: middlewares,
+		ReturnGroup: group,
+	})
+	return group
+}
+
 func (s *Server) ServeFiles(prefix, root string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -261,6 +402,19 @@ func (g *RouteGroup) Get(path string, handlers ...any) error {
 	return err
 }
 
+func (g *RouteGroup) Head(path string, handlers ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.HeadCalls = append(g.parent.HeadCalls, RouteCall{
+		Path:     g.prefix + path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
 func (g *RouteGroup) Post(path string, handlers ...any) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -313,6 +467,86 @@ func (g *RouteGroup) Delete(path string, handlers ...any) error {
 	return err
 }
 
+func (g *RouteGroup) Options(path string, handlers ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.OptionsCalls = append(g.parent.OptionsCalls, RouteCall{
+		Path:     g.prefix + path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (g *RouteGroup) Any(path string, handlers ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.AnyCalls = append(g.parent.AnyCalls, RouteCall{
+		Path:     g.prefix + path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (g *RouteGroup) Match(methods []string, path string, handlers ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.MatchCalls = append(g.parent.MatchCalls, MatchCall{
+		Methods:  methods,
+		Path:     g.prefix + path,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (g *RouteGroup) Mount(prefix string, h http.Handler, middlewares ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.MountCalls = append(g.parent.MountCalls, MountCall{
+		Prefix:      g.prefix + prefix,
+		Handler:     h,
+		Middlewares: middlewares,
+		Err:         err,
+	})
+	return err
+}
+
+func (g *RouteGroup) NotFound(handlers ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.NotFoundCalls = append(g.parent.NotFoundCalls, RouteCall{
+		Path:     g.prefix,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
+func (g *RouteGroup) MethodNotAllowed(handlers ...any) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := error(nil)
+	g.parent.MethodNotAllowedCalls = append(g.parent.MethodNotAllowedCalls, RouteCall{
+		Path:     g.prefix,
+		Handlers: handlers,
+		Err:      err,
+	})
+	return err
+}
+
 func (g *RouteGroup) Use(middlewares ...any) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
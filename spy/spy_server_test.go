@@ -2,6 +2,7 @@ package spy
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"testing"
 
@@ -61,6 +62,9 @@ func TestServerSpy(t *testing.T) {
 			{s.Put, &s.PutCalls, "/put", []any{handler}},
 			{s.Patch, &s.PatchCalls, "/patch", []any{handler}},
 			{s.Delete, &s.DeleteCalls, "/delete", []any{handler}},
+			{s.Head, &s.HeadCalls, "/head", []any{handler}},
+			{s.Options, &s.OptionsCalls, "/options", []any{handler}},
+			{s.Any, &s.AnyCalls, "/any", []any{handler}},
 		}
 
 		for _, tt := range tests {
@@ -70,6 +74,13 @@ func TestServerSpy(t *testing.T) {
 			assert.Equal(t, tt.path, (*tt.calls)[0].Path)
 			assert.Equal(t, tt.handler, (*tt.calls)[0].Handlers)
 		}
+
+		methods := []string{http.MethodPut, http.MethodPatch}
+		err := s.Match(methods, "/match", handler)
+		assert.NoError(t, err)
+		assert.Equal(t, len(s.MatchCalls), 1)
+		assert.Equal(t, s.MatchCalls[0].Methods, methods)
+		assert.Equal(t, s.MatchCalls[0].Path, "/match")
 	})
 
 	t.Run("Route records prefix and calls function", func(t *testing.T) {
@@ -98,6 +109,45 @@ func TestServerSpy(t *testing.T) {
 		assert.Equal(t, len(s.GroupCalls[0].Middlewares), 1)
 	})
 
+	t.Run("Host records pattern and returns RouteGroup", func(t *testing.T) {
+		s := NewServer()
+		middleware := func(_ *i9.Context) error { return nil }
+
+		group := s.Host(":tenant.example.com", middleware)
+		_, ok := group.(*RouteGroup)
+		assert.True(t, ok)
+		assert.Equal(t, len(s.HostCalls), 1)
+		assert.Equal(t, ":tenant.example.com", s.HostCalls[0].Prefix)
+		assert.Equal(t, len(s.HostCalls[0].Middlewares), 1)
+	})
+
+	t.Run("Mount records prefix and handler", func(t *testing.T) {
+		s := NewServer()
+		handler := http.NotFoundHandler()
+
+		err := s.Mount("/debug", handler)
+		assert.NoError(t, err)
+		err = s.Group("/api").Mount("/admin", handler)
+		assert.NoError(t, err)
+		assert.Equal(t, len(s.MountCalls), 2)
+		assert.Equal(t, s.MountCalls[0].Prefix, "/debug")
+		assert.Equal(t, s.MountCalls[1].Prefix, "/api/admin")
+	})
+
+	t.Run("NotFound and MethodNotAllowed record group prefix", func(t *testing.T) {
+		s := NewServer()
+		handler := func() error { return nil }
+
+		assert.NoError(t, s.NotFound(handler))
+		assert.NoError(t, s.Group("/api").NotFound(handler))
+		assert.NoError(t, s.Group("/api").MethodNotAllowed(handler))
+		assert.Equal(t, len(s.NotFoundCalls), 2)
+		assert.Equal(t, s.NotFoundCalls[0].Path, "")
+		assert.Equal(t, s.NotFoundCalls[1].Path, "/api")
+		assert.Equal(t, len(s.MethodNotAllowedCalls), 1)
+		assert.Equal(t, s.MethodNotAllowedCalls[0].Path, "/api")
+	})
+
 	t.Run("ServeFiles records calls", func(t *testing.T) {
 		s := NewServer()
 		prefix := "/static"
@@ -160,6 +210,9 @@ func TestRouteGroupSpy(t *testing.T) {
 			{group.Put, &s.PutCalls, "/users/1", []any{handler}},
 			{group.Patch, &s.PatchCalls, "/users/1", []any{handler}},
 			{group.Delete, &s.DeleteCalls, "/users/1", []any{handler}},
+			{group.Head, &s.HeadCalls, "/users", []any{handler}},
+			{group.Options, &s.OptionsCalls, "/users", []any{handler}},
+			{group.Any, &s.AnyCalls, "/echo", []any{handler}},
 		}
 
 		for _, tt := range tests {
@@ -171,6 +224,11 @@ func TestRouteGroupSpy(t *testing.T) {
 			// Reset calls for next test
 			*tt.calls = []RouteCall{}
 		}
+
+		err := group.Match([]string{http.MethodGet}, "/match", handler)
+		assert.NoError(t, err)
+		assert.Equal(t, len(s.MatchCalls), 1)
+		assert.Equal(t, s.MatchCalls[0].Path, "/api/match")
 	})
 
 	t.Run("RouteGroup Use records middleware", func(t *testing.T) {